@@ -0,0 +1,67 @@
+// Command zvault-agent runs alongside an application and materializes
+// secrets from ZVault Cloud into files, env-files, or a local unix socket,
+// so the application itself never needs to hold a ZVault token.
+//
+// Usage:
+//
+//	zvault-agent -config zvault-agent.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nicosalm/zvault/pkg/agent"
+	zvault "github.com/nicosalm/zvault/sdks/go"
+)
+
+func main() {
+	configPath := flag.String("config", "zvault-agent.yaml", "path to the agent config file")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	cfg, err := agent.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	baseURL := firstNonEmpty(cfg.BaseURL, os.Getenv("ZVAULT_URL"), "https://api.zvault.cloud")
+	authenticator, err := agent.BuildAuthenticator(cfg.AutoAuth, baseURL)
+	if err != nil {
+		return err
+	}
+
+	client := zvault.NewWithConfig(zvault.Config{
+		Token:     cfg.AutoAuth.Token,
+		BaseURL:   cfg.BaseURL,
+		OrgID:     cfg.OrgID,
+		ProjectID: cfg.ProjectID,
+		Auth:      authenticator,
+	})
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	log.Printf("zvault-agent: starting, polling every %s", cfg.PollPeriod)
+	return agent.New(cfg, client).Run(ctx)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
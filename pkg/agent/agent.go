@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	zvault "github.com/nicosalm/zvault/sdks/go"
+)
+
+// Agent polls ZVault Cloud on an interval, keeps a set of templates in sync
+// with the fetched secrets, and optionally exposes those secrets over a
+// local unix socket so other processes don't each need their own token.
+type Agent struct {
+	cfg    *Config
+	client *zvault.Client
+
+	mu      sync.RWMutex
+	secrets map[string]string
+
+	socket *socketServer
+	child  *exec.Cmd
+}
+
+// New builds an Agent from a loaded Config. The caller is responsible for
+// constructing the underlying zvault.Client (so auth method selection goes
+// through the normal SDK config path).
+func New(cfg *Config, client *zvault.Client) *Agent {
+	return &Agent{cfg: cfg, client: client, secrets: map[string]string{}}
+}
+
+// Run polls until ctx is cancelled, rendering templates on every change,
+// signaling the supervised child (if configured), and serving
+// the local socket API (if configured) for the duration.
+func (a *Agent) Run(ctx context.Context) error {
+	if a.cfg.Cache.SocketPath != "" {
+		srv, err := newSocketServer(a.cfg.Cache.SocketPath, a.currentSecrets)
+		if err != nil {
+			return fmt.Errorf("agent: failed to start socket server: %w", err)
+		}
+		a.socket = srv
+		go srv.serve()
+		defer srv.close()
+	}
+
+	if a.cfg.Exec.Command != "" {
+		if err := a.startChild(); err != nil {
+			return err
+		}
+		defer a.stopChild()
+	}
+
+	if err := a.refresh(ctx); err != nil {
+		log.Printf("agent: initial fetch failed, will retry: %v", err)
+	}
+
+	for {
+		delay := a.cfg.PollPeriod + jitter(a.cfg.PollPeriod)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+			if err := a.refresh(ctx); err != nil {
+				log.Printf("agent: refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+func (a *Agent) refresh(ctx context.Context) error {
+	secrets, failed, err := a.client.GetAll(ctx, a.cfg.Env)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		log.Printf("agent: %d key(s) failed to fetch and are missing from this refresh: %v", len(failed), failed)
+	}
+
+	a.mu.Lock()
+	a.secrets = secrets
+	a.mu.Unlock()
+
+	changed := false
+	for _, spec := range a.cfg.Templates {
+		rewrote, err := renderTemplate(spec, secrets)
+		if err != nil {
+			log.Printf("agent: %v", err)
+			continue
+		}
+		if rewrote {
+			changed = true
+			if spec.Command != "" {
+				if err := runCommand(spec.Command); err != nil {
+					log.Printf("agent: reload command for %s failed: %v", spec.Destination, err)
+				}
+			}
+		}
+	}
+
+	if changed && a.child != nil {
+		a.reloadChild()
+	}
+	return nil
+}
+
+func (a *Agent) currentSecrets() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	out := make(map[string]string, len(a.secrets))
+	for k, v := range a.secrets {
+		out[k] = v
+	}
+	return out
+}
+
+// runCommand execs a one-shot reload hook to completion.
+func runCommand(command string) error {
+	return exec.Command("/bin/sh", "-c", command).Run()
+}
+
+// jitter returns a random duration in [0, d/10) to avoid every agent in a
+// fleet polling ZVault Cloud at the exact same instant.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d) / 10))
+}
+
+func (a *Agent) startChild() error {
+	sig, ok := signalNames[a.cfg.Exec.ReloadSignal]
+	if !ok {
+		sig = syscall.SIGHUP
+	}
+	a.cfg.Exec.reloadSig = sig
+
+	cmd := exec.Command("/bin/sh", "-c", a.cfg.Exec.Command)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("agent: failed to start exec.command %q: %w", a.cfg.Exec.Command, err)
+	}
+	a.child = cmd
+	return nil
+}
+
+func (a *Agent) reloadChild() {
+	if a.child == nil || a.child.Process == nil {
+		return
+	}
+	if err := a.child.Process.Signal(a.cfg.Exec.reloadSig); err != nil {
+		log.Printf("agent: failed to signal child process: %v", err)
+	}
+}
+
+func (a *Agent) stopChild() {
+	if a.child == nil || a.child.Process == nil {
+		return
+	}
+	_ = a.child.Process.Signal(syscall.SIGTERM)
+	_, _ = a.child.Process.Wait()
+}
+
+var signalNames = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGTERM": syscall.SIGTERM,
+}
@@ -0,0 +1,29 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/nicosalm/zvault/pkg/auth"
+)
+
+// BuildAuthenticator turns an AutoAuthConfig into a pkg/auth Authenticator,
+// mirroring the Terraform provider's buildAuthenticator. It returns
+// nil, nil for the default "token" method, where AutoAuthConfig.Token is
+// used directly as Config.Token instead of Config.Auth.
+func BuildAuthenticator(cfg AutoAuthConfig, baseURL string) (auth.Authenticator, error) {
+	switch cfg.Method {
+	case "", "token":
+		return nil, nil
+	case "kubernetes":
+		return auth.KubernetesAuth{BaseURL: baseURL, Role: cfg.Role}, nil
+	case "oidc":
+		if cfg.JWT == "" {
+			return nil, fmt.Errorf("agent: auto_auth.jwt is required when auto_auth.method = \"oidc\"")
+		}
+		return auth.OIDCAuth{BaseURL: baseURL, Role: cfg.Role, JWT: cfg.JWT}, nil
+	case "aws_iam":
+		return auth.AWSIAMAuth{BaseURL: baseURL, Role: cfg.Role, Region: cfg.Region}, nil
+	default:
+		return nil, fmt.Errorf("agent: unknown auto_auth.method %q", cfg.Method)
+	}
+}
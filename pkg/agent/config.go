@@ -0,0 +1,122 @@
+// Package agent implements the ZVault Agent: a sidecar process that polls
+// ZVault Cloud for secrets and materializes them into files, env-files, or
+// a local unix-socket API that other processes on the host can use instead
+// of talking to the cloud API directly.
+package agent
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level zvault-agent configuration file format.
+//
+// Example:
+//
+//	org_id: org_123
+//	project_id: proj_abc
+//	auto_auth:
+//	  method: kubernetes
+//	  role: my-role
+//	cache:
+//	  socket_path: /tmp/zvault-agent.sock
+//	template:
+//	  - source: templates/app.env.tmpl
+//	    destination: /etc/app/app.env
+//	    perms: "0600"
+//	    command: "systemctl reload app"
+type Config struct {
+	OrgID     string         `yaml:"org_id"`
+	ProjectID string         `yaml:"project_id"`
+	Env       string         `yaml:"env"`
+	BaseURL   string         `yaml:"base_url"`
+	AutoAuth  AutoAuthConfig `yaml:"auto_auth"`
+	Cache     CacheConfig    `yaml:"cache"`
+	Templates []TemplateSpec `yaml:"template"`
+	Exec      ExecConfig     `yaml:"exec"`
+
+	// PollPeriodRaw is a time.ParseDuration string (e.g. "30s"); yaml.v3
+	// has no special handling for time.Duration, so it can't unmarshal a
+	// human duration directly. Resolved into PollPeriod by LoadConfig.
+	PollPeriodRaw string        `yaml:"poll_period"`
+	PollPeriod    time.Duration `yaml:"-"`
+}
+
+// ExecConfig supervises a single child process (the envconsul/consul-template
+// pattern) that gets signaled whenever a managed template changes.
+type ExecConfig struct {
+	Command      string `yaml:"command"`
+	ReloadSignal string `yaml:"reload_signal"` // defaults to SIGHUP
+
+	reloadSig syscall.Signal // resolved at Agent.startChild time
+}
+
+// AutoAuthConfig configures how the agent itself authenticates to ZVault
+// Cloud. Method selects one of the pkg/auth Authenticator implementations;
+// see BuildAuthenticator.
+type AutoAuthConfig struct {
+	Method string `yaml:"method"` // "token" (default), "kubernetes", "oidc", "aws_iam"
+	Role   string `yaml:"role"`
+	Token  string `yaml:"token"`
+	JWT    string `yaml:"jwt"`    // required when method = "oidc"
+	Region string `yaml:"region"` // optional AWS region override, method = "aws_iam"
+}
+
+// CacheConfig controls the agent's local API surface.
+type CacheConfig struct {
+	SocketPath string `yaml:"socket_path"`
+}
+
+// TemplateSpec describes one file the agent keeps in sync with ZVault Cloud.
+type TemplateSpec struct {
+	Source      string `yaml:"source"`
+	Destination string `yaml:"destination"`
+	Perms       string `yaml:"perms"` // e.g. "0600"; defaults to 0600
+	Command     string `yaml:"command"`
+}
+
+const defaultPollPeriod = 30 * time.Second
+
+// LoadConfig reads and validates a zvault-agent config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("agent: failed to read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("agent: failed to parse config %s: %w", path, err)
+	}
+
+	if cfg.OrgID == "" {
+		return nil, fmt.Errorf("agent: config missing org_id")
+	}
+	if cfg.ProjectID == "" {
+		return nil, fmt.Errorf("agent: config missing project_id")
+	}
+	if cfg.Env == "" {
+		cfg.Env = "development"
+	}
+	if cfg.PollPeriodRaw != "" {
+		d, err := time.ParseDuration(cfg.PollPeriodRaw)
+		if err != nil {
+			return nil, fmt.Errorf("agent: invalid poll_period %q: %w", cfg.PollPeriodRaw, err)
+		}
+		cfg.PollPeriod = d
+	}
+	if cfg.PollPeriod == 0 {
+		cfg.PollPeriod = defaultPollPeriod
+	}
+	for i, t := range cfg.Templates {
+		if t.Source == "" || t.Destination == "" {
+			return nil, fmt.Errorf("agent: template[%d] requires source and destination", i)
+		}
+	}
+
+	return &cfg, nil
+}
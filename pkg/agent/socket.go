@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+)
+
+// socketServer exposes the agent's cached secrets over a unix domain
+// socket so other SDKs on the same host (Gin/Fiber middlewares, scripts)
+// can read them without each holding their own ZVault Cloud token.
+type socketServer struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+func newSocketServer(path string, secrets func() map[string]string) (*socketServer, error) {
+	_ = os.Remove(path) // stale socket from a previous crash
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/secrets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(secrets())
+	})
+
+	return &socketServer{
+		listener: ln,
+		server:   &http.Server{Handler: mux},
+	}, nil
+}
+
+func (s *socketServer) serve() {
+	_ = s.server.Serve(s.listener)
+}
+
+func (s *socketServer) close() {
+	_ = s.server.Close()
+	_ = os.Remove(s.listener.Addr().String())
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+)
+
+// renderTemplate renders one TemplateSpec's source file against the given
+// secrets map and atomically rewrites the destination if the rendered
+// content changed. It reports whether the destination was actually
+// (re)written, so callers know whether to fire the reload command.
+func renderTemplate(spec TemplateSpec, secrets map[string]string) (bool, error) {
+	src, err := os.ReadFile(spec.Source)
+	if err != nil {
+		return false, fmt.Errorf("agent: failed to read template %s: %w", spec.Source, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(spec.Source)).Funcs(template.FuncMap{
+		"secret":  func(key string) string { return secrets[key] },
+		"secrets": func() map[string]string { return secrets },
+	}).Parse(string(src))
+	if err != nil {
+		return false, fmt.Errorf("agent: failed to parse template %s: %w", spec.Source, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, secrets); err != nil {
+		return false, fmt.Errorf("agent: failed to render template %s: %w", spec.Source, err)
+	}
+
+	existing, err := os.ReadFile(spec.Destination)
+	if err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return false, nil
+	}
+
+	mode := os.FileMode(0o600)
+	if spec.Perms != "" {
+		parsed, err := strconv.ParseUint(spec.Perms, 8, 32)
+		if err != nil {
+			return false, fmt.Errorf("agent: invalid perms %q for %s: %w", spec.Perms, spec.Destination, err)
+		}
+		mode = os.FileMode(parsed)
+	}
+
+	if err := writeFileAtomic(spec.Destination, buf.Bytes(), mode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeFileAtomic writes to a temp file in the destination's directory and
+// renames over the target, so readers never observe a partial write.
+func writeFileAtomic(destination string, data []byte, mode os.FileMode) error {
+	dir := filepath.Dir(destination)
+	tmp, err := os.CreateTemp(dir, ".zvault-agent-*")
+	if err != nil {
+		return fmt.Errorf("agent: failed to create temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("agent: failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("agent: failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return fmt.Errorf("agent: failed to chmod %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, destination); err != nil {
+		return fmt.Errorf("agent: failed to rename %s to %s: %w", tmpPath, destination, err)
+	}
+	return nil
+}
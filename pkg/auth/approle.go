@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// AppRoleAuth logs in with a RoleID/SecretID pair, the standard shape for
+// CI/CD workloads that can hold a scoped SecretID (e.g. injected from a
+// pipeline's own secret store) without a human or long-lived static token.
+type AppRoleAuth struct {
+	BaseURL    string
+	RoleID     string
+	SecretID   string
+	HTTPClient *http.Client
+}
+
+func (a AppRoleAuth) Login(ctx context.Context) (*Token, error) {
+	body := map[string]string{
+		"role_id":   a.RoleID,
+		"secret_id": a.SecretID,
+	}
+	return postLogin(ctx, a.HTTPClient, a.BaseURL, "/v1/cloud/auth/approle/login", body, nil)
+}
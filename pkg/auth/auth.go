@@ -0,0 +1,38 @@
+// Package auth provides pluggable authentication backends for ZVault
+// clients (the Terraform provider, the Go SDK, and the Gin/Fiber
+// middlewares). Each backend exchanges some ambient credential — a
+// Kubernetes ServiceAccount token, a caller-supplied OIDC/JWT, signed AWS
+// STS headers — for a short-lived ZVault service token (zvt_...), mirroring
+// how Vault's auth methods work.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Token is a ZVault service token with its expiry, as returned by any
+// /v1/cloud/auth/*/login endpoint.
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// Expired reports whether the token is past (or within buffer of) expiry.
+func (t *Token) Expired(buffer time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return true
+	}
+	if t.ExpiresAt.IsZero() {
+		return false // non-expiring token (e.g. a static service token)
+	}
+	return time.Now().Add(buffer).After(t.ExpiresAt)
+}
+
+// Authenticator exchanges some credential for a ZVault access token.
+// Implementations should be safe to call Login on repeatedly; callers are
+// expected to cache the result and call Login again only once it is near
+// expiry or a request comes back 401.
+type Authenticator interface {
+	Login(ctx context.Context) (*Token, error)
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// AWSIAMAuth authenticates by signing an STS GetCallerIdentity request with
+// the instance/task/role's AWS credentials and handing the signed headers
+// to ZVault Cloud, which verifies them against STS itself. This mirrors
+// Vault's aws auth method's iam login flow and requires no long-lived
+// secret beyond whatever AWS credential chain is already available
+// (instance profile, ECS task role, IRSA, etc).
+type AWSIAMAuth struct {
+	BaseURL string
+	// Role is the ZVault auth role bound to this AWS principal.
+	Role string
+	// Region overrides the AWS region used to build the STS request.
+	// Defaults to the environment/config-resolved region.
+	Region string
+	// HTTPClient is an optional custom client; defaults to a 10s timeout client.
+	HTTPClient *http.Client
+}
+
+func (a AWSIAMAuth) Login(ctx context.Context) (*Token, error) {
+	var optFns []func(*awsconfig.LoadOptions) error
+	if a.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(a.Region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to load AWS config: %w", err)
+	}
+
+	stsClient := sts.NewFromConfig(cfg)
+	presignClient := sts.NewPresignClient(stsClient)
+
+	presigned, err := presignClient.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to presign GetCallerIdentity: %w", err)
+	}
+
+	// ZVault Cloud re-issues the presigned request itself and checks that
+	// STS resolves it to a principal permitted by Role, so we only need
+	// to forward the signed URL and the headers the signature covers.
+	body := map[string]any{
+		"role":            a.Role,
+		"sts_request_url": presigned.URL,
+		"sts_headers":     headersToMap(presigned.SignedHeader),
+	}
+	return postLogin(ctx, a.HTTPClient, a.BaseURL, "/v1/cloud/auth/aws-iam/login", body, nil)
+}
+
+func headersToMap(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, vs := range h {
+		if len(vs) > 0 {
+			out[k] = vs[0]
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CachedAuthenticator wraps an Authenticator so repeated callers share one
+// cached token instead of each calling Login. This is the piece every
+// ZVault integration (Terraform provider, Go SDK, Gin/Fiber middlewares)
+// needs on top of a raw Authenticator, so it lives here rather than being
+// reimplemented per integration.
+type CachedAuthenticator struct {
+	Authenticator Authenticator
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewCachedAuthenticator wraps a.
+func NewCachedAuthenticator(a Authenticator) *CachedAuthenticator {
+	return &CachedAuthenticator{Authenticator: a}
+}
+
+// Token returns a usable access token, logging in (and caching the result)
+// only when there is none yet or the cached one is expired. Pass force to
+// bypass the cache, e.g. after an upstream 401.
+func (c *CachedAuthenticator) Token(ctx context.Context, force bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !force && !c.token.Expired(0) {
+		return c.token.AccessToken, nil
+	}
+
+	tok, err := c.Authenticator.Login(ctx)
+	if err != nil {
+		return "", fmt.Errorf("auth: login failed: %w", err)
+	}
+	c.token = tok
+	return tok.AccessToken, nil
+}
+
+// ExpiresAt returns the current cached token's expiry and true, or false if
+// no token has been cached yet or the token never expires (zero ExpiresAt).
+func (c *CachedAuthenticator) ExpiresAt() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil || c.token.ExpiresAt.IsZero() {
+		return time.Time{}, false
+	}
+	return c.token.ExpiresAt, true
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// KubernetesAuth logs in with the pod's projected ServiceAccount JWT,
+// mirroring Vault's kubernetes auth method: the JWT proves which
+// ServiceAccount is asking, and Role selects what it's allowed to access.
+type KubernetesAuth struct {
+	// BaseURL is the ZVault Cloud API base URL, e.g. https://api.zvault.cloud.
+	BaseURL string
+	// Role is the ZVault auth role bound to this ServiceAccount.
+	Role string
+	// TokenPath overrides where the ServiceAccount JWT is read from.
+	// Defaults to the standard projected-volume path.
+	TokenPath string
+	// HTTPClient is an optional custom client; defaults to a 10s timeout client.
+	HTTPClient *http.Client
+}
+
+func (a KubernetesAuth) Login(ctx context.Context) (*Token, error) {
+	tokenPath := a.TokenPath
+	if tokenPath == "" {
+		tokenPath = defaultServiceAccountTokenPath
+	}
+
+	jwt, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read ServiceAccount token at %s: %w", tokenPath, err)
+	}
+
+	body := map[string]string{
+		"role": a.Role,
+		"jwt":  string(jwt),
+	}
+	return postLogin(ctx, a.HTTPClient, a.BaseURL, "/v1/cloud/auth/kubernetes/login", body, nil)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const userAgent = "zvault-auth/0.1.0"
+
+// loginResponse is the common shape every /v1/cloud/auth/*/login endpoint
+// returns.
+type loginResponse struct {
+	Token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"` // seconds
+	} `json:"token"`
+}
+
+// postLogin POSTs body to baseURL+path and parses the common token
+// envelope, shared by every backend in this package.
+func postLogin(ctx context.Context, client *http.Client, baseURL, path string, body any, extraHeaders map[string]string) (*Token, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to marshal login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	for k, v := range extraHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read login response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("auth: login failed with HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var parsed loginResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("auth: failed to parse login response: %w", err)
+	}
+
+	return &Token{
+		AccessToken: parsed.Token.AccessToken,
+		ExpiresAt:   time.Now().Add(time.Duration(parsed.Token.ExpiresIn) * time.Second),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// OIDCAuth exchanges a caller-supplied OIDC/JWT (e.g. a GitHub Actions
+// `id-token`, or a token minted by an external identity provider) for a
+// ZVault service token. Role selects what the exchanged token is allowed
+// to access.
+type OIDCAuth struct {
+	BaseURL    string
+	Role       string
+	JWT        string
+	HTTPClient *http.Client
+}
+
+func (a OIDCAuth) Login(ctx context.Context) (*Token, error) {
+	body := map[string]string{
+		"role": a.Role,
+		"jwt":  a.JWT,
+	}
+	return postLogin(ctx, a.HTTPClient, a.BaseURL, "/v1/cloud/auth/oidc/login", body, nil)
+}
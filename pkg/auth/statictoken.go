@@ -0,0 +1,14 @@
+package auth
+
+import "context"
+
+// StaticTokenAuth wraps a fixed service token (zvt_...) as an
+// Authenticator, so callers that want a single Config.Auth code path can
+// use it even when they don't need token exchange.
+type StaticTokenAuth struct {
+	Token string
+}
+
+func (a StaticTokenAuth) Login(_ context.Context) (*Token, error) {
+	return &Token{AccessToken: a.Token}, nil // zero ExpiresAt: never expires
+}
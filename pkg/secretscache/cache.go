@@ -0,0 +1,209 @@
+// Package secretscache provides a shared, pluggable cache for secrets
+// fetched from ZVault Cloud, used by the Gin and Fiber middlewares (and
+// any other per-request integration) so that multiple middlewares in the
+// same process — different envs, different projects — don't collide on a
+// single package-level cache and don't stampede the API on concurrent
+// refreshes.
+package secretscache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Key identifies one cacheable secrets set.
+type Key struct {
+	OrgID     string
+	ProjectID string
+	Env       string
+}
+
+// FetchFunc retrieves the current secrets for a Key from upstream (ZVault
+// Cloud, the local agent socket, etc). Return ErrNotFound for a 404 so the
+// cache can apply negative caching instead of retrying every request.
+type FetchFunc func(ctx context.Context, key Key) (map[string]string, error)
+
+// ErrNotFound should be returned by a FetchFunc when upstream reports the
+// env/project doesn't exist, so the cache can negatively cache the miss.
+var ErrNotFound = errors.New("secretscache: not found")
+
+// Cache is the interface both framework adapters depend on.
+type Cache interface {
+	// Get returns the cached secrets for key, fetching via fetch on a
+	// miss. On a stale-but-not-hard-expired entry it returns the stale
+	// copy immediately and refreshes asynchronously (stale-while-revalidate).
+	// Past hard_ttl it fetches synchronously; if that fetch fails and a
+	// stale (non-negative) entry still exists, that stale entry is
+	// returned rather than the error, so an upstream outage degrades to
+	// serving old secrets instead of none.
+	Get(ctx context.Context, key Key, fetch FetchFunc) (map[string]string, error)
+	// Set seeds/overwrites the cache for key.
+	Set(key Key, secrets map[string]string)
+	// Invalidate drops any cached entry for key.
+	Invalidate(key Key)
+	// Refresh forces a synchronous fetch and updates the cache.
+	Refresh(ctx context.Context, key Key, fetch FetchFunc) (map[string]string, error)
+}
+
+// Options configures a TTLCache.
+type Options struct {
+	// SoftTTL is how long an entry is served without triggering a
+	// background refresh. Default: 1 minute.
+	SoftTTL time.Duration
+	// HardTTL is how long a stale entry may still be served while a
+	// refresh is in flight, before callers are made to wait on a
+	// synchronous fetch. Default: 10x SoftTTL.
+	HardTTL time.Duration
+	// NegativeTTL caches a not-found result for this long. Default: 30s.
+	NegativeTTL time.Duration
+	// JitterFrac adds +/- this fraction of randomness to every TTL to
+	// avoid thundering herds across many processes. Default: 0.1.
+	JitterFrac float64
+}
+
+func (o Options) withDefaults() Options {
+	if o.SoftTTL == 0 {
+		o.SoftTTL = time.Minute
+	}
+	if o.HardTTL == 0 {
+		o.HardTTL = o.SoftTTL * 10
+	}
+	if o.NegativeTTL == 0 {
+		o.NegativeTTL = 30 * time.Second
+	}
+	if o.JitterFrac == 0 {
+		o.JitterFrac = 0.1
+	}
+	return o
+}
+
+type entry struct {
+	secrets  map[string]string
+	negative bool
+	softAt   time.Time
+	hardAt   time.Time
+}
+
+// TTLCache is a keyed, in-memory, TTL+SWR cache with singleflight-guarded
+// refreshes: concurrent callers for the same key that all miss collapse
+// into one upstream fetch.
+type TTLCache struct {
+	opts  Options
+	mu    sync.RWMutex
+	store map[Key]*entry
+	group singleflight.Group
+}
+
+// NewTTLCache builds a TTLCache with the given options (zero value is fine
+// and uses the documented defaults).
+func NewTTLCache(opts Options) *TTLCache {
+	return &TTLCache{
+		opts:  opts.withDefaults(),
+		store: make(map[Key]*entry),
+	}
+}
+
+func (c *TTLCache) Get(ctx context.Context, key Key, fetch FetchFunc) (map[string]string, error) {
+	c.mu.RLock()
+	e, ok := c.store[key]
+	c.mu.RUnlock()
+
+	now := time.Now()
+	switch {
+	case ok && now.Before(e.softAt):
+		// Fresh.
+		return copyOrNotFound(e)
+	case ok && now.Before(e.hardAt):
+		// Stale but usable — serve it and kick a background refresh.
+		go func() {
+			_, _ = c.Refresh(context.WithoutCancel(ctx), key, fetch)
+		}()
+		return copyOrNotFound(e)
+	default:
+		// No usable entry — caller must wait for a synchronous fetch.
+		secrets, err := c.Refresh(ctx, key, fetch)
+		if err != nil && ok && !e.negative {
+			// Graceful degradation: upstream is down and our only copy
+			// is already past hard_ttl, but serving a very stale entry
+			// beats the caller coming up with no secrets at all.
+			return copyOrNotFound(e)
+		}
+		return secrets, err
+	}
+}
+
+func (c *TTLCache) Refresh(ctx context.Context, key Key, fetch FetchFunc) (map[string]string, error) {
+	v, err, _ := c.group.Do(cacheGroupKey(key), func() (any, error) {
+		secrets, ferr := fetch(ctx, key)
+		if ferr != nil {
+			if errors.Is(ferr, ErrNotFound) {
+				c.storeNegative(key)
+				return nil, ErrNotFound
+			}
+			return nil, ferr
+		}
+		c.Set(key, secrets)
+		return secrets, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]string), nil
+}
+
+func (c *TTLCache) Set(key Key, secrets map[string]string) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store[key] = &entry{
+		secrets: secrets,
+		softAt:  now.Add(withJitter(c.opts.SoftTTL, c.opts.JitterFrac)),
+		hardAt:  now.Add(withJitter(c.opts.HardTTL, c.opts.JitterFrac)),
+	}
+}
+
+func (c *TTLCache) storeNegative(key Key) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store[key] = &entry{
+		negative: true,
+		softAt:   now.Add(withJitter(c.opts.NegativeTTL, c.opts.JitterFrac)),
+		hardAt:   now.Add(withJitter(c.opts.NegativeTTL, c.opts.JitterFrac)),
+	}
+}
+
+func (c *TTLCache) Invalidate(key Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.store, key)
+}
+
+func copyOrNotFound(e *entry) (map[string]string, error) {
+	if e.negative {
+		return nil, ErrNotFound
+	}
+	out := make(map[string]string, len(e.secrets))
+	for k, v := range e.secrets {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func cacheGroupKey(key Key) string {
+	return key.OrgID + "/" + key.ProjectID + "/" + key.Env
+}
+
+func withJitter(d time.Duration, frac float64) time.Duration {
+	if d <= 0 || frac <= 0 {
+		return d
+	}
+	delta := float64(d) * frac
+	offset := (rand.Float64()*2 - 1) * delta
+	return d + time.Duration(offset)
+}
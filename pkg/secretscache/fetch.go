@@ -0,0 +1,126 @@
+package secretscache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nicosalm/zvault/pkg/auth"
+)
+
+// HTTPFetcher is the shared upstream fetch logic previously duplicated
+// between the Gin and Fiber middlewares: GET the env's secrets from ZVault
+// Cloud with a small bounded retry, returning ErrNotFound on 404 so the
+// cache can apply negative caching.
+type HTTPFetcher struct {
+	BaseURL    string
+	Token      string
+	UserAgent  string
+	HTTPClient *http.Client
+	MaxRetries int
+
+	// Authenticator, if set, takes priority over Token: the bearer token
+	// is resolved (and cached/refreshed) through it on every fetch.
+	Authenticator *auth.CachedAuthenticator
+}
+
+// Fetch implements FetchFunc.
+func (f HTTPFetcher) Fetch(ctx context.Context, key Key) (map[string]string, error) {
+	client := f.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	maxRetries := f.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 2
+	}
+
+	token, err := f.resolveToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/v1/cloud/orgs/%s/projects/%s/envs/%s/secrets", f.BaseURL, key.OrgID, key.ProjectID, key.Env)
+
+	var lastErr error
+	reauthed := false
+	for i := 0; i <= maxRetries; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", f.UserAgent)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			if i < maxRetries {
+				sleepBackoff(ctx, i)
+			}
+			continue
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return parseSecrets(body), nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, ErrNotFound
+		case resp.StatusCode == http.StatusUnauthorized && f.Authenticator != nil && !reauthed && i < maxRetries:
+			// Token may have expired early or been revoked — force a
+			// fresh login once and retry with it.
+			if token, err = f.resolveToken(ctx, true); err != nil {
+				return nil, err
+			}
+			reauthed = true
+			continue
+		case resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests:
+			return nil, fmt.Errorf("secretscache: HTTP %d", resp.StatusCode)
+		}
+
+		lastErr = fmt.Errorf("secretscache: HTTP %d", resp.StatusCode)
+		if i < maxRetries {
+			sleepBackoff(ctx, i)
+		}
+	}
+
+	return nil, fmt.Errorf("secretscache: request failed: %w", lastErr)
+}
+
+func (f HTTPFetcher) resolveToken(ctx context.Context, force bool) (string, error) {
+	if f.Authenticator == nil {
+		return f.Token, nil
+	}
+	return f.Authenticator.Token(ctx, force)
+}
+
+func sleepBackoff(ctx context.Context, attempt int) {
+	select {
+	case <-time.After(time.Duration(300*(1<<attempt)) * time.Millisecond):
+	case <-ctx.Done():
+	}
+}
+
+func parseSecrets(body []byte) map[string]string {
+	var resp struct {
+		Secrets []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"secrets"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return map[string]string{}
+	}
+	result := make(map[string]string, len(resp.Secrets))
+	for _, s := range resp.Secrets {
+		result[s.Key] = s.Value
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package secretscache
+
+import "github.com/nicosalm/zvault/pkg/auth"
+
+// MiddlewareOptions holds the pluggable bits a framework adapter
+// (zvaultgin, zvaultfiber, ...) needs, so each adapter stays a thin
+// wrapper around a shared Cache instead of rolling its own.
+type MiddlewareOptions struct {
+	Cache         Cache
+	Authenticator *auth.CachedAuthenticator
+}
+
+// Option configures MiddlewareOptions.
+type Option func(*MiddlewareOptions)
+
+// WithCache overrides the default TTLCache, e.g. to share one Cache across
+// multiple middlewares in the same process, or to plug in a different
+// implementation entirely.
+func WithCache(cache Cache) Option {
+	return func(o *MiddlewareOptions) { o.Cache = cache }
+}
+
+// WithAuthenticator makes the middleware fetch its bearer token through an
+// Authenticator (Kubernetes ServiceAccount, OIDC, AWS IAM, ...) instead of
+// a static ZVAULT_TOKEN.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(o *MiddlewareOptions) { o.Authenticator = auth.NewCachedAuthenticator(a) }
+}
+
+// NewMiddlewareOptions applies opts over sane defaults.
+func NewMiddlewareOptions(opts ...Option) MiddlewareOptions {
+	o := MiddlewareOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.Cache == nil {
+		o.Cache = NewTTLCache(Options{})
+	}
+	return o
+}
@@ -11,78 +11,63 @@
 //	    secrets := zvaultfiber.GetSecrets(c)
 //	    return c.JSON(fiber.Map{"db": secrets["DATABASE_URL"]})
 //	})
+//
+// Caching is delegated to pkg/secretscache, which can be shared across
+// multiple Middleware instances (e.g. different envs/projects in the same
+// process) via zvaultfiber.WithCache — see secretscache.Option.
 package zvaultfiber
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"sync"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/nicosalm/zvault/pkg/secretscache"
 )
 
 const (
-	defaultBaseURL  = "https://api.zvault.cloud"
-	defaultTimeout  = 10 * time.Second
-	defaultCacheTTL = 5 * time.Minute
-	maxRetries      = 2
-	secretsKey      = "zvault_secrets"
+	defaultBaseURL = "https://api.zvault.cloud"
+	secretsKey     = "zvault_secrets"
+	userAgent      = "zvault-fiber/0.1.0"
 )
 
-type cachedSecrets struct {
-	data      map[string]string
-	expiresAt time.Time
-}
+// Option configures Middleware. Re-exported so callers don't need to
+// import pkg/secretscache directly for the common case.
+type Option = secretscache.Option
 
-var (
-	cache   *cachedSecrets
-	cacheMu sync.RWMutex
-)
+// WithCache overrides the default cache — see secretscache.WithCache.
+var WithCache = secretscache.WithCache
 
-// Middleware returns a Fiber middleware that fetches secrets from ZVault Cloud
-// and stores them in c.Locals("zvault_secrets").
-func Middleware(env string) fiber.Handler {
-	token := envOr("ZVAULT_TOKEN", "")
+// WithAuthenticator authenticates via an auth.Authenticator (Kubernetes,
+// OIDC, AWS IAM, ...) instead of a static ZVAULT_TOKEN.
+var WithAuthenticator = secretscache.WithAuthenticator
+
+// Middleware returns a Fiber middleware that fetches secrets from ZVault
+// Cloud through a shared secretscache.Cache and stores them in
+// c.Locals("zvault_secrets").
+func Middleware(env string, opts ...Option) fiber.Handler {
 	orgID := envOr("ZVAULT_ORG_ID", "")
 	projectID := envOr("ZVAULT_PROJECT_ID", "")
 	baseURL := envOr("ZVAULT_URL", defaultBaseURL)
+	cfg := secretscache.NewMiddlewareOptions(opts...)
 
-	if token == "" || orgID == "" || projectID == "" {
+	token := envOr("ZVAULT_TOKEN", "")
+	if (token == "" && cfg.Authenticator == nil) || orgID == "" || projectID == "" {
 		return func(c *fiber.Ctx) error {
 			c.Locals(secretsKey, map[string]string{})
 			return c.Next()
 		}
 	}
 
-	return func(c *fiber.Ctx) error {
-		cacheMu.RLock()
-		if cache != nil && cache.expiresAt.After(time.Now()) {
-			c.Locals(secretsKey, cache.data)
-			cacheMu.RUnlock()
-			return c.Next()
-		}
-		cacheMu.RUnlock()
+	fetcher := secretscache.HTTPFetcher{BaseURL: baseURL, Token: token, UserAgent: userAgent, Authenticator: cfg.Authenticator}
+	key := secretscache.Key{OrgID: orgID, ProjectID: projectID, Env: env}
 
-		secrets, err := fetchSecrets(baseURL, token, orgID, projectID, env)
+	return func(c *fiber.Ctx) error {
+		secrets, err := cfg.Cache.Get(c.Context(), key, fetcher.Fetch)
 		if err != nil {
-			cacheMu.RLock()
-			if cache != nil {
-				c.Locals(secretsKey, cache.data)
-			} else {
-				c.Locals(secretsKey, map[string]string{})
-			}
-			cacheMu.RUnlock()
+			c.Locals(secretsKey, map[string]string{})
 			return c.Next()
 		}
-
-		cacheMu.Lock()
-		cache = &cachedSecrets{data: secrets, expiresAt: time.Now().Add(defaultCacheTTL)}
-		cacheMu.Unlock()
-
 		c.Locals(secretsKey, secrets)
 		return c.Next()
 	}
@@ -101,67 +86,6 @@ func GetSecrets(c *fiber.Ctx) map[string]string {
 	return s
 }
 
-func fetchSecrets(baseURL, token, orgID, projectID, env string) (map[string]string, error) {
-	url := fmt.Sprintf("%s/v1/cloud/orgs/%s/projects/%s/envs/%s/secrets", baseURL, orgID, projectID, env)
-
-	var lastErr error
-	client := &http.Client{Timeout: defaultTimeout}
-
-	for i := 0; i <= maxRetries; i++ {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "zvault-fiber/0.1.0")
-
-		resp, err := client.Do(req)
-		if err != nil {
-			lastErr = err
-			if i < maxRetries {
-				time.Sleep(time.Duration(300*(1<<i)) * time.Millisecond)
-			}
-			continue
-		}
-
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return parseSecrets(body), nil
-		}
-
-		lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
-		if resp.StatusCode < 500 && resp.StatusCode != 429 {
-			return nil, lastErr
-		}
-
-		if i < maxRetries {
-			time.Sleep(time.Duration(300*(1<<i)) * time.Millisecond)
-		}
-	}
-
-	return nil, fmt.Errorf("request failed: %w", lastErr)
-}
-
-func parseSecrets(body []byte) map[string]string {
-	var resp struct {
-		Secrets []struct {
-			Key   string `json:"key"`
-			Value string `json:"value"`
-		} `json:"secrets"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return map[string]string{}
-	}
-	result := make(map[string]string, len(resp.Secrets))
-	for _, s := range resp.Secrets {
-		result[s.Key] = s.Value
-	}
-	return result
-}
-
 func envOr(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
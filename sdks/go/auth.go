@@ -0,0 +1,52 @@
+package zvault
+
+import (
+	"context"
+	"time"
+)
+
+// resolveToken returns the bearer token for the next request: the static
+// Config.Token when no Authenticator is configured, otherwise the
+// Authenticator's cached token (forcing a fresh login when force is true,
+// e.g. after a 401).
+func (c *Client) resolveToken(ctx context.Context, force bool) (string, error) {
+	if c.cachedAuth == nil {
+		return c.token, nil
+	}
+	return c.cachedAuth.Token(ctx, force)
+}
+
+// startAuthRefreshOnce launches the background goroutine that keeps the
+// Authenticator's token fresh at ~80% of its lifetime, so request-path
+// logins only happen on an unexpected early expiry or revocation. No-op
+// when Config.Auth wasn't set.
+func (c *Client) startAuthRefreshOnce() {
+	if c.cachedAuth == nil {
+		return
+	}
+	c.authRefreshOnce.Do(func() {
+		go c.runAuthRefresh()
+	})
+}
+
+func (c *Client) runAuthRefresh() {
+	ctx := context.Background()
+	for {
+		expiresAt, ok := c.cachedAuth.ExpiresAt()
+		if !ok {
+			return // non-expiring token (e.g. StaticTokenAuth) — nothing to refresh
+		}
+
+		sleep := time.Until(expiresAt) * 4 / 5 // refresh at ~80% of the lifetime
+		if sleep < 0 {
+			sleep = 0
+		}
+		time.Sleep(sleep)
+
+		if _, err := c.cachedAuth.Token(ctx, true); err != nil {
+			// Leave the stale (possibly still-valid) token in place; the
+			// next request-path 401 will retry the login.
+			time.Sleep(retryBaseDelay)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package zvault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// batchFetchConcurrency bounds the per-key worker pool used as a fallback
+// when the server doesn't support the batchGet endpoint yet.
+const batchFetchConcurrency = 8
+
+// BatchResult is the result of GetBatch. Failed carries keys that could not
+// be fetched (even via the per-key fallback) so partial failures are
+// visible instead of silently dropped.
+type BatchResult struct {
+	Secrets map[string]SecretEntry
+	Failed  []string
+}
+
+type batchGetResponse struct {
+	Secrets []SecretEntry `json:"secrets"`
+}
+
+// GetBatch fetches multiple secrets by key in as few round-trips as
+// possible. When the server advertises batchGet support (or
+// Config.UseBatchFetch forces it), this is a single POST; otherwise it
+// falls back to a bounded worker pool of per-key GETs.
+func (c *Client) GetBatch(ctx context.Context, env string, keys []string) (*BatchResult, error) {
+	env = c.resolveEnv(env)
+	if err := c.requireProjectConfig(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return &BatchResult{Secrets: map[string]SecretEntry{}}, nil
+	}
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets", c.orgID, c.projectID, env)
+
+	if c.shouldTryBatch() {
+		secrets, err := c.batchGetViaEndpoint(ctx, path, keys)
+		if err == nil {
+			c.recordBatchSupport(true)
+			return &BatchResult{Secrets: secrets, Failed: missingKeys(keys, secrets)}, nil
+		}
+		if !isUnsupportedBatch(err) {
+			return nil, err
+		}
+		c.recordBatchSupport(false)
+	}
+
+	return c.batchGetViaWorkerPool(ctx, path, keys)
+}
+
+// shouldTryBatch reports whether the next GetBatch should attempt the
+// batchGet endpoint: always when Config.UseBatchFetch is set, otherwise
+// until a prior attempt has proven it unsupported (404/501).
+func (c *Client) shouldTryBatch() bool {
+	if c.forceBatch {
+		return true
+	}
+	c.batchMu.Lock()
+	defer c.batchMu.Unlock()
+	return !c.batchProbed || c.batchOK
+}
+
+func (c *Client) recordBatchSupport(ok bool) {
+	c.batchMu.Lock()
+	defer c.batchMu.Unlock()
+	c.batchProbed = true
+	c.batchOK = ok
+}
+
+func (c *Client) batchGetViaEndpoint(ctx context.Context, envPath string, keys []string) (map[string]SecretEntry, error) {
+	var resp batchGetResponse
+	if err := c.request(ctx, http.MethodPost, envPath+":batchGet", map[string]any{"keys": keys}, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]SecretEntry, len(resp.Secrets))
+	for _, s := range resp.Secrets {
+		out[s.Key] = s
+	}
+	return out, nil
+}
+
+// missingKeys returns the keys requested but not present in the batchGet
+// response, so a caller filtering on a nonexistent key sees it in Failed
+// instead of the endpoint path silently returning fewer secrets than asked.
+func missingKeys(requested []string, got map[string]SecretEntry) []string {
+	var missing []string
+	for _, k := range requested {
+		if _, ok := got[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+func isUnsupportedBatch(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented
+	}
+	return false
+}
+
+func (c *Client) batchGetViaWorkerPool(ctx context.Context, envPath string, keys []string) (*BatchResult, error) {
+	var mu sync.Mutex
+	result := &BatchResult{Secrets: make(map[string]SecretEntry, len(keys))}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(batchFetchConcurrency)
+
+	for _, key := range keys {
+		key := key
+		g.Go(func() error {
+			var resp secretResponse
+			secretPath := fmt.Sprintf("%s/%s", envPath, url.PathEscape(key))
+			err := c.request(gctx, http.MethodGet, secretPath, nil, &resp)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed = append(result.Failed, key)
+				return nil // partial failures don't abort the rest of the pool
+			}
+			result.Secrets[resp.Secret.Key] = resp.Secret
+			return nil
+		})
+	}
+	_ = g.Wait() // errors are carried per-key in result.Failed, never returned here
+
+	return result, nil
+}
@@ -0,0 +1,196 @@
+package zvault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrCacheMiss is returned by CacheStore.Load when nothing is persisted
+// for an env yet.
+var ErrCacheMiss = errors.New("zvault: cache miss")
+
+// CacheStore persists the in-memory secrets cache across process
+// restarts, so an app crash-looping during a ZVault outage still comes up
+// with last-known-good secrets instead of none. When Config.CacheStore is
+// set, setCache/setCachedKey save every cache update to it, and GetAll reads
+// it back (via getCachedStale) only when a network fetch fails and the env
+// has no in-memory entry; plug in Redis or any other backend by
+// implementing this interface.
+type CacheStore interface {
+	// Load returns the persisted secrets and their expiry for env, or
+	// ErrCacheMiss if nothing is stored.
+	Load(env string) (secrets map[string]string, expiresAt time.Time, err error)
+	// Save persists secrets and their expiry for env.
+	Save(env string, secrets map[string]string, expiresAt time.Time) error
+}
+
+// FileCacheStore persists each env's secrets as AES-GCM-encrypted JSON
+// under $XDG_CACHE_HOME/zvault/<orgID>/<projectID>/<env>.json.enc (see
+// os.UserCacheDir). The encryption key is derived via HKDF-SHA256 from the
+// service token, so a stolen cache file is useless without the same token
+// that already grants API access.
+type FileCacheStore struct {
+	OrgID     string
+	ProjectID string
+	Token     string
+
+	// Dir overrides the cache root (default: os.UserCacheDir()/zvault).
+	Dir string
+}
+
+// NewFileCacheStore creates a FileCacheStore scoped to one org/project,
+// keyed off token.
+func NewFileCacheStore(orgID, projectID, token string) *FileCacheStore {
+	return &FileCacheStore{OrgID: orgID, ProjectID: projectID, Token: token}
+}
+
+type fileCacheEnvelope struct {
+	Secrets   map[string]string `json:"secrets"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+func (f *FileCacheStore) path(env string) (string, error) {
+	dir := f.Dir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("zvault: failed to resolve cache dir: %w", err)
+		}
+		dir = filepath.Join(base, "zvault")
+	}
+	return filepath.Join(dir, f.OrgID, f.ProjectID, env+".json.enc"), nil
+}
+
+// Load implements CacheStore.
+func (f *FileCacheStore) Load(env string) (map[string]string, time.Time, error) {
+	path, err := f.path(env)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	ciphertext, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, ErrCacheMiss
+		}
+		return nil, time.Time{}, fmt.Errorf("zvault: failed to read cache file %s: %w", path, err)
+	}
+
+	plaintext, err := f.decrypt(ciphertext)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("zvault: failed to decrypt cache file %s: %w", path, err)
+	}
+
+	var envelope fileCacheEnvelope
+	if err := json.Unmarshal(plaintext, &envelope); err != nil {
+		return nil, time.Time{}, fmt.Errorf("zvault: failed to parse cache file %s: %w", path, err)
+	}
+	return envelope.Secrets, envelope.ExpiresAt, nil
+}
+
+// Save implements CacheStore.
+func (f *FileCacheStore) Save(env string, secrets map[string]string, expiresAt time.Time) error {
+	path, err := f.path(env)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("zvault: failed to create cache dir for %s: %w", path, err)
+	}
+
+	plaintext, err := json.Marshal(fileCacheEnvelope{Secrets: secrets, ExpiresAt: expiresAt})
+	if err != nil {
+		return fmt.Errorf("zvault: failed to marshal cache entry for %s: %w", env, err)
+	}
+
+	ciphertext, err := f.encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("zvault: failed to encrypt cache entry for %s: %w", env, err)
+	}
+
+	return writeCacheFileAtomic(path, ciphertext)
+}
+
+func (f *FileCacheStore) deriveKey() ([]byte, error) {
+	key := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, []byte(f.Token), nil, []byte("zvault-file-cache-v1"))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (f *FileCacheStore) encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (f *FileCacheStore) decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, data := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+func (f *FileCacheStore) gcm() (cipher.AEAD, error) {
+	key, err := f.deriveKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeCacheFileAtomic writes to a temp file in the destination's
+// directory and renames over the target, so a concurrent Load never
+// observes a partial write.
+func writeCacheFileAtomic(destination string, data []byte) error {
+	dir := filepath.Dir(destination)
+	tmp, err := os.CreateTemp(dir, ".zvault-cache-*")
+	if err != nil {
+		return fmt.Errorf("zvault: failed to create temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("zvault: failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("zvault: failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		return fmt.Errorf("zvault: failed to chmod %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, destination); err != nil {
+		return fmt.Errorf("zvault: failed to rename %s to %s: %w", tmpPath, destination, err)
+	}
+	return nil
+}
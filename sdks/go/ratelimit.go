@@ -0,0 +1,93 @@
+package zvault
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryDelay caps any single retry wait, whether computed by exponential
+// backoff or taken from a server-supplied Retry-After, so a misbehaving or
+// very conservative server can't stall a request indefinitely.
+const maxRetryDelay = 60 * time.Second
+
+// recordRateLimitHeaders mirrors the most recent X-RateLimit-* headers onto
+// the Client so HealthStatus can report how close callers are to being
+// throttled. No-op when the server doesn't send them.
+func (c *Client) recordRateLimitHeaders(headers http.Header) {
+	remaining := headers.Get("X-RateLimit-Remaining")
+	if remaining == "" {
+		return
+	}
+	n, err := strconv.Atoi(remaining)
+	if err != nil {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimitRemaining = n
+	if resetAt, ok := parseRateLimitReset(headers.Get("X-RateLimit-Reset")); ok {
+		c.rateLimitResetAt = resetAt
+	}
+}
+
+// sleepForRetry waits before the next retry attempt. A Retry-After header
+// takes priority over the computed exponential backoff; failing that, an
+// X-RateLimit-Reset window acts as a floor. Either way the wait is capped
+// at maxRetryDelay and aborts early on ctx.Done().
+func sleepForRetry(ctx context.Context, attempt int, headers http.Header) {
+	delay := backoffDelay(attempt)
+
+	if ra, ok := parseRetryAfter(headers.Get("Retry-After")); ok {
+		delay = ra
+	} else if resetAt, ok := parseRateLimitReset(headers.Get("X-RateLimit-Reset")); ok {
+		if until := time.Until(resetAt); until > delay {
+			delay = until
+		}
+	}
+
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which per RFC 7231 is either
+// a number of seconds or an HTTP-date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header as a Unix epoch
+// timestamp in seconds, the common convention this header follows.
+func parseRateLimitReset(v string) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
@@ -0,0 +1,182 @@
+package zvault
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"text/template"
+)
+
+// Render executes tmpl against env's secrets, exposing them via the
+// {{ secret "KEY" }} and {{ secrets }} template funcs (tmpl must already
+// have been parsed with those names registered, even with placeholder
+// implementations — see RenderToFile). This is the file/template-rendering
+// counterpart to InjectIntoEnv, for services that read config from disk
+// (nginx, envoy, .env-style tools) instead of their own environment.
+func (c *Client) Render(ctx context.Context, env string, tmpl *template.Template, w io.Writer) error {
+	secrets, _, err := c.GetAll(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	bound := tmpl.Funcs(template.FuncMap{
+		"secret":  func(key string) string { return secrets[key] },
+		"secrets": func() map[string]string { return secrets },
+	})
+	if err := bound.Execute(w, secrets); err != nil {
+		return fmt.Errorf("zvault: failed to render template %s: %w", tmpl.Name(), err)
+	}
+	return nil
+}
+
+// RenderToFile renders the template at tmplPath and atomically rewrites
+// outPath, but only if the rendered content actually changed. mode is
+// applied to outPath; pass 0 for the default of 0600.
+func (c *Client) RenderToFile(ctx context.Context, env, tmplPath, outPath string, mode os.FileMode) error {
+	if mode == 0 {
+		mode = 0o600
+	}
+
+	src, err := os.ReadFile(tmplPath)
+	if err != nil {
+		return fmt.Errorf("zvault: failed to read template %s: %w", tmplPath, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(template.FuncMap{
+		"secret":  func(string) string { return "" },
+		"secrets": func() map[string]string { return nil },
+	}).Parse(string(src))
+	if err != nil {
+		return fmt.Errorf("zvault: failed to parse template %s: %w", tmplPath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Render(ctx, env, tmpl, &buf); err != nil {
+		return err
+	}
+
+	if existing, err := os.ReadFile(outPath); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return nil
+	}
+
+	return writeRenderFileAtomic(outPath, buf.Bytes(), mode)
+}
+
+// TemplateSpec describes one template RunTemplate keeps in sync with an
+// env's secrets, envconsul/consul-template style.
+type TemplateSpec struct {
+	Env         string
+	Source      string // template file path
+	Destination string // rendered output file path
+
+	// Perms is applied to Destination. Defaults to 0600.
+	Perms os.FileMode
+
+	// Command, if set, is started once via "/bin/sh -c" and signaled
+	// (ReloadSignal, default SIGHUP) every time Destination is rewritten,
+	// instead of being re-run from scratch.
+	Command      string
+	ReloadSignal os.Signal
+}
+
+// RunTemplate renders spec once, then uses a Watcher to re-render
+// Destination every time spec.Env's secrets change, signaling spec.Command
+// (if set) after each rewrite. It blocks until ctx is cancelled.
+func (c *Client) RunTemplate(ctx context.Context, spec TemplateSpec) error {
+	sig := spec.ReloadSignal
+	if sig == nil {
+		sig = syscall.SIGHUP
+	}
+
+	// render reports whether Destination's content changed, so the child
+	// is only signaled after an actual rewrite.
+	render := func() (bool, error) {
+		before, _ := os.ReadFile(spec.Destination)
+		if err := c.RenderToFile(ctx, spec.Env, spec.Source, spec.Destination, spec.Perms); err != nil {
+			return false, err
+		}
+		after, _ := os.ReadFile(spec.Destination)
+		return !bytes.Equal(before, after), nil
+	}
+
+	if _, err := render(); err != nil {
+		return err
+	}
+
+	var child *exec.Cmd
+	if spec.Command != "" {
+		child = exec.Command("/bin/sh", "-c", spec.Command)
+		if err := child.Start(); err != nil {
+			return fmt.Errorf("zvault: failed to start command %q: %w", spec.Command, err)
+		}
+		defer stopTemplateChild(child)
+	}
+
+	watcher, err := c.Watch(ctx, spec.Env, WatchOptions{})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-watcher.Updates():
+			rewrote, err := render()
+			if err != nil {
+				return err
+			}
+			if rewrote && child != nil && child.Process != nil {
+				if err := child.Process.Signal(sig); err != nil {
+					return fmt.Errorf("zvault: failed to signal reload for %q: %w", spec.Command, err)
+				}
+			}
+		case <-watcher.Errors():
+			// GetAll's own graceful degradation already serves the last
+			// good secrets on a transient failure; nothing to do here
+			// beyond leaving the last-rendered file in place.
+		}
+	}
+}
+
+func stopTemplateChild(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	_, _ = cmd.Process.Wait()
+}
+
+// writeRenderFileAtomic writes to a temp file in the destination's
+// directory and renames over the target, so readers never observe a
+// partial write.
+func writeRenderFileAtomic(destination string, data []byte, mode os.FileMode) error {
+	dir := filepath.Dir(destination)
+	tmp, err := os.CreateTemp(dir, ".zvault-render-*")
+	if err != nil {
+		return fmt.Errorf("zvault: failed to create temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("zvault: failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("zvault: failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return fmt.Errorf("zvault: failed to chmod %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, destination); err != nil {
+		return fmt.Errorf("zvault: failed to rename %s to %s: %w", tmpPath, destination, err)
+	}
+	return nil
+}
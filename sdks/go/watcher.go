@@ -0,0 +1,278 @@
+package zvault
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// SecretsEvent describes what changed in an env between two refreshes.
+type SecretsEvent struct {
+	Env     string
+	Secrets map[string]string
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	// RefreshFraction is how far into CacheTTL to schedule the next
+	// refresh, e.g. 0.8 refreshes at 80% of TTL. Default: 0.8.
+	RefreshFraction float64
+	// MaxBackoff caps the exponential backoff applied after consecutive
+	// failures; up to 20% jitter is added on top of the capped value.
+	// Default: 5 minutes.
+	MaxBackoff time.Duration
+}
+
+func (o WatchOptions) withDefaults() WatchOptions {
+	if o.RefreshFraction <= 0 {
+		o.RefreshFraction = 0.8
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Minute
+	}
+	return o
+}
+
+// Watcher proactively keeps one env's secrets warm in the Client's cache
+// and reports changes, instead of callers polling GetAll themselves.
+type Watcher struct {
+	env     string
+	updates chan SecretsEvent
+	errs    chan error
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// Updates delivers a SecretsEvent every time a refresh observes a change.
+func (w *Watcher) Updates() <-chan SecretsEvent { return w.updates }
+
+// Errors delivers refresh failures. Cached values are preserved across
+// errors (graceful degradation) — a value on this channel does not mean
+// the watcher stopped.
+func (w *Watcher) Errors() <-chan error { return w.errs }
+
+// Stop ends the watcher. Safe to call more than once.
+func (w *Watcher) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+// watchEntry is one scheduled refresh, ordered by renewAfter in the
+// Client's min-heap (modeled on Vault's renewal-heap pattern).
+type watchEntry struct {
+	env        string
+	w          *Watcher
+	opts       WatchOptions
+	prev       map[string]string
+	failures   int
+	renewAfter time.Time
+	index      int // maintained by container/heap
+}
+
+type watchHeap []*watchEntry
+
+func (h watchHeap) Len() int            { return len(h) }
+func (h watchHeap) Less(i, j int) bool  { return h[i].renewAfter.Before(h[j].renewAfter) }
+func (h watchHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i]; h[i].index = i; h[j].index = j }
+func (h *watchHeap) Push(x interface{}) { e := x.(*watchEntry); e.index = len(*h); *h = append(*h, e) }
+func (h *watchHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+// Watch returns a handle that keeps env's cache warm and emits a
+// SecretsEvent whenever a scheduled refresh observes a change. The
+// refresh schedule and a single background scheduler goroutine are shared
+// across every Watcher on this Client.
+func (c *Client) Watch(ctx context.Context, env string, opts WatchOptions) (*Watcher, error) {
+	env = c.resolveEnv(env)
+	if err := c.requireProjectConfig(); err != nil {
+		return nil, err
+	}
+	opts = opts.withDefaults()
+
+	w := &Watcher{
+		env:     env,
+		updates: make(chan SecretsEvent, 1),
+		errs:    make(chan error, 1),
+		stop:    make(chan struct{}),
+	}
+
+	c.startSchedulerOnce(ctx)
+
+	entry := &watchEntry{env: env, w: w, opts: opts, renewAfter: time.Now()}
+	c.watchMu.Lock()
+	heap.Push(&c.watchHeap, entry)
+	c.watchMu.Unlock()
+	c.wakeScheduler()
+
+	go func() {
+		<-w.stop
+		c.removeWatchEntry(entry)
+	}()
+
+	return w, nil
+}
+
+// StopAll stops every Watcher created on this Client.
+func (c *Client) StopAll() {
+	c.watchMu.Lock()
+	entries := append([]*watchEntry(nil), c.watchHeap...)
+	c.watchMu.Unlock()
+	for _, e := range entries {
+		e.w.Stop()
+	}
+}
+
+func (c *Client) startSchedulerOnce(ctx context.Context) {
+	c.schedulerOnce.Do(func() {
+		c.watchWake = make(chan struct{}, 1)
+		go c.runScheduler(ctx)
+	})
+}
+
+func (c *Client) wakeScheduler() {
+	select {
+	case c.watchWake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) removeWatchEntry(target *watchEntry) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	if target.index >= 0 && target.index < len(c.watchHeap) && c.watchHeap[target.index] == target {
+		heap.Remove(&c.watchHeap, target.index)
+	}
+}
+
+// runScheduler is the single goroutine that sleeps until the heap root is
+// due, refreshes it, diffs against the previous snapshot, and reschedules.
+func (c *Client) runScheduler(ctx context.Context) {
+	for {
+		c.watchMu.Lock()
+		var wait time.Duration
+		if len(c.watchHeap) == 0 {
+			wait = time.Hour // nothing scheduled; wake on the next Watch call
+		} else {
+			wait = time.Until(c.watchHeap[0].renewAfter)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		c.watchMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		case <-c.watchWake:
+			continue
+		}
+
+		c.watchMu.Lock()
+		if len(c.watchHeap) == 0 || time.Now().Before(c.watchHeap[0].renewAfter) {
+			c.watchMu.Unlock()
+			continue
+		}
+		entry := heap.Pop(&c.watchHeap).(*watchEntry)
+		c.watchMu.Unlock()
+
+		c.refreshWatchEntry(ctx, entry)
+
+		select {
+		case <-entry.w.stop:
+			// Watcher was stopped while its refresh was in flight.
+		default:
+			c.watchMu.Lock()
+			heap.Push(&c.watchHeap, entry)
+			c.watchMu.Unlock()
+		}
+	}
+}
+
+func (c *Client) refreshWatchEntry(ctx context.Context, entry *watchEntry) {
+	secrets, failed, err := c.GetAll(ctx, entry.env)
+	if err != nil {
+		entry.failures++
+		entry.renewAfter = time.Now().Add(backoff(entry.failures, entry.opts.MaxBackoff))
+		select {
+		case entry.w.errs <- err:
+		default:
+		}
+		return
+	}
+	entry.failures = 0
+
+	if len(failed) > 0 {
+		// Non-fatal: the refresh still produced a usable (if incomplete)
+		// secrets map, but let callers watching errs know some keys didn't
+		// come through instead of silently handing them a smaller map.
+		select {
+		case entry.w.errs <- fmt.Errorf("zvault: %s: failed to fetch %d key(s): %v", entry.env, len(failed), failed):
+		default:
+		}
+	}
+
+	added, changed, removed := diffSecrets(entry.prev, secrets)
+	if len(added) > 0 || len(changed) > 0 || len(removed) > 0 {
+		event := SecretsEvent{Env: entry.env, Secrets: secrets, Added: added, Changed: changed, Removed: removed}
+		select {
+		case entry.w.updates <- event:
+		default:
+			// Drop if the consumer hasn't drained the previous event —
+			// the next refresh carries a superset of the state anyway.
+		}
+	}
+	entry.prev = secrets
+
+	ttl := c.cacheTTL
+	renewIn := time.Duration(float64(ttl) * entry.opts.RefreshFraction)
+	entry.renewAfter = time.Now().Add(withJitter(renewIn))
+}
+
+func diffSecrets(prev, next map[string]string) (added, changed, removed []string) {
+	for k, v := range next {
+		old, existed := prev[k]
+		if !existed {
+			added = append(added, k)
+		} else if old != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range prev {
+		if _, ok := next[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	return added, changed, removed
+}
+
+// backoff applies capped exponential backoff after consecutive failures.
+func backoff(failures int, max time.Duration) time.Duration {
+	d := max
+	if failures < 32 { // larger exponents would overflow time.Duration
+		if exp := retryBaseDelay * time.Duration(math.Pow(2, float64(failures))); exp < max {
+			d = exp
+		}
+	}
+	return withJitter(d)
+}
+
+func withJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	jitter := time.Duration(rand.Int64N(int64(float64(d) * 0.2)))
+	return d + jitter
+}
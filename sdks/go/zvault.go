@@ -6,7 +6,7 @@
 // Usage:
 //
 //	client := zvault.New(os.Getenv("ZVAULT_TOKEN"))
-//	secrets, err := client.GetAll(ctx, "production")
+//	secrets, _, err := client.GetAll(ctx, "production")
 //	dbURL := secrets["DATABASE_URL"]
 package zvault
 
@@ -23,6 +23,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/nicosalm/zvault/pkg/auth"
 )
 
 const (
@@ -69,6 +71,25 @@ type Config struct {
 
 	// HTTPClient is an optional custom HTTP client. If nil, a default is created.
 	HTTPClient *http.Client
+
+	// Auth, if set, takes priority over Token: the client logs in through
+	// it on first use, injects the resulting token into every request,
+	// and refreshes it in the background at ~80% of its ExpiresAt. See
+	// pkg/auth for built-in implementations (StaticTokenAuth, AppRoleAuth,
+	// OIDCAuth, KubernetesAuth, AWSIAMAuth).
+	Auth auth.Authenticator
+
+	// UseBatchFetch forces GetAll/GetBatch to call the batchGet endpoint
+	// without probing for support first. Leave false to auto-detect: the
+	// client tries batchGet once, remembers whether the server supports
+	// it, and falls back to a per-key worker pool otherwise.
+	UseBatchFetch bool
+
+	// CacheStore, if set, mirrors the in-memory cache to persistent
+	// storage so a crash-restart during a ZVault outage still has
+	// last-known-good secrets instead of none. See FileCacheStore for the
+	// built-in on-disk implementation.
+	CacheStore CacheStore
 }
 
 // SecretEntry represents a single secret from the API.
@@ -95,6 +116,13 @@ type HealthStatus struct {
 	LatencyMs     int64
 	CachedSecrets int
 	LastRefresh   time.Time
+
+	// RateLimitRemaining and RateLimitResetAt mirror the most recent
+	// X-RateLimit-* headers seen from the API, so callers can tell they
+	// are being throttled. RateLimitRemaining is -1 until the server has
+	// sent a rate-limit header at least once.
+	RateLimitRemaining int
+	RateLimitResetAt   time.Time
 }
 
 type secretResponse struct {
@@ -128,9 +156,28 @@ type Client struct {
 	maxRetries int
 	httpClient *http.Client
 
+	cachedAuth      *auth.CachedAuthenticator
+	authRefreshOnce sync.Once
+
+	forceBatch  bool
+	batchMu     sync.Mutex
+	batchProbed bool
+	batchOK     bool
+
+	cacheStore CacheStore
+
+	rateLimitMu        sync.Mutex
+	rateLimitRemaining int
+	rateLimitResetAt   time.Time
+
 	mu          sync.RWMutex
 	cache       map[string]*cacheEntry // env -> cached secrets
 	lastRefresh time.Time
+
+	watchMu       sync.Mutex
+	watchHeap     watchHeap
+	watchWake     chan struct{}
+	schedulerOnce sync.Once
 }
 
 // New creates a new ZVault client with the given token.
@@ -142,8 +189,8 @@ func New(token string) *Client {
 // NewWithConfig creates a new ZVault client with full configuration.
 func NewWithConfig(cfg Config) *Client {
 	token := firstNonEmpty(cfg.Token, os.Getenv("ZVAULT_TOKEN"))
-	if token == "" {
-		panic("zvault: missing token — set ZVAULT_TOKEN env var or pass Config.Token")
+	if token == "" && cfg.Auth == nil {
+		panic("zvault: missing token — set ZVAULT_TOKEN env var, pass Config.Token, or set Config.Auth")
 	}
 
 	baseURL := firstNonEmpty(cfg.BaseURL, os.Getenv("ZVAULT_URL"), defaultBaseURL)
@@ -173,53 +220,76 @@ func NewWithConfig(cfg Config) *Client {
 		httpClient = &http.Client{Timeout: timeout}
 	}
 
-	return &Client{
-		token:      token,
-		baseURL:    baseURL,
-		orgID:      orgID,
-		projectID:  projectID,
-		defaultEnv: defaultEnv,
-		cacheTTL:   cacheTTL,
-		maxRetries: maxRetries,
-		httpClient: httpClient,
-		cache:      make(map[string]*cacheEntry),
-	}
+	c := &Client{
+		token:              token,
+		baseURL:            baseURL,
+		orgID:              orgID,
+		projectID:          projectID,
+		defaultEnv:         defaultEnv,
+		cacheTTL:           cacheTTL,
+		maxRetries:         maxRetries,
+		httpClient:         httpClient,
+		cache:              make(map[string]*cacheEntry),
+		forceBatch:         cfg.UseBatchFetch,
+		cacheStore:         cfg.CacheStore,
+		rateLimitRemaining: -1,
+	}
+	if cfg.Auth != nil {
+		c.cachedAuth = auth.NewCachedAuthenticator(cfg.Auth)
+	}
+	return c
 }
 
 // GetAll fetches all secrets for an environment. Results are cached in-memory.
 // On network failure, returns last-known cached values (graceful degradation).
 // Pass empty string for env to use the default environment.
-func (c *Client) GetAll(ctx context.Context, env string) (map[string]string, error) {
+//
+// failed carries keys that ListKeys reported but whose value could not be
+// fetched (see BatchResult.Failed) — callers that need to know about partial
+// failures instead of silently getting fewer secrets than expected should
+// check it rather than assuming len(secrets) == number of keys in the env.
+//
+// Internally this is ListKeys followed by a single GetBatch call, so when the
+// server supports batchGet a large env costs two round-trips instead of N+1.
+// Without batchGet support, GetBatch still issues one GET per key, run in
+// parallel through a bounded worker pool.
+func (c *Client) GetAll(ctx context.Context, env string) (secrets map[string]string, failed []string, err error) {
 	env = c.resolveEnv(env)
 	if err := c.requireProjectConfig(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Fetch key list
-	var keysResp secretKeysResponse
-	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets", c.orgID, c.projectID, env)
-	if err := c.request(ctx, http.MethodGet, path, nil, &keysResp); err != nil {
-		// Graceful degradation
-		if cached := c.getCached(env); cached != nil {
-			return cached, nil
+	keys, err := c.ListKeys(ctx, env)
+	if err != nil {
+		// Graceful degradation: serve a cached copy even if it's expired
+		// rather than come up empty, preferring an in-memory entry but
+		// falling back to the persistent CacheStore (if any).
+		if cached := c.getCachedStale(env); cached != nil {
+			return cached, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
+	}
+
+	keyNames := make([]string, len(keys))
+	for i, k := range keys {
+		keyNames[i] = k.Key
 	}
 
-	// Fetch each secret value
-	secrets := make(map[string]string, len(keysResp.Keys))
-	for _, k := range keysResp.Keys {
-		var resp secretResponse
-		secretPath := fmt.Sprintf("%s/%s", path, url.PathEscape(k.Key))
-		if err := c.request(ctx, http.MethodGet, secretPath, nil, &resp); err != nil {
-			continue // skip individual failures
+	batch, err := c.GetBatch(ctx, env, keyNames)
+	if err != nil {
+		if cached := c.getCachedStale(env); cached != nil {
+			return cached, nil, nil
 		}
-		secrets[resp.Secret.Key] = resp.Secret.Value
+		return nil, nil, err
+	}
+
+	secrets = make(map[string]string, len(batch.Secrets))
+	for k, s := range batch.Secrets {
+		secrets[k] = s.Value
 	}
 
-	// Update cache
 	c.setCache(env, secrets)
-	return secrets, nil
+	return secrets, batch.Failed, nil
 }
 
 // Get fetches a single secret by key. Checks cache first.
@@ -297,7 +367,7 @@ func (c *Client) Delete(ctx context.Context, key, env string) error {
 // Existing vars are NOT overwritten unless overwrite is true.
 // Returns the number of variables injected.
 func (c *Client) InjectIntoEnv(ctx context.Context, env string, overwrite bool) (int, error) {
-	secrets, err := c.GetAll(ctx, env)
+	secrets, _, err := c.GetAll(ctx, env)
 	if err != nil {
 		return 0, err
 	}
@@ -330,11 +400,18 @@ func (c *Client) Healthy(ctx context.Context) HealthStatus {
 	lastRefresh := c.lastRefresh
 	c.mu.RUnlock()
 
+	c.rateLimitMu.Lock()
+	rateLimitRemaining := c.rateLimitRemaining
+	rateLimitResetAt := c.rateLimitResetAt
+	c.rateLimitMu.Unlock()
+
 	return HealthStatus{
-		OK:            err == nil,
-		LatencyMs:     time.Since(start).Milliseconds(),
-		CachedSecrets: cached,
-		LastRefresh:   lastRefresh,
+		OK:                 err == nil,
+		LatencyMs:          time.Since(start).Milliseconds(),
+		CachedSecrets:      cached,
+		LastRefresh:        lastRefresh,
+		RateLimitRemaining: rateLimitRemaining,
+		RateLimitResetAt:   rateLimitResetAt,
 	}
 }
 
@@ -357,14 +434,22 @@ func (c *Client) requireProjectConfig() error {
 	return nil
 }
 
-func (c *Client) getCached(env string) map[string]string {
+// getCachedStale serves an expired entry rather than nothing, and hydrates
+// from the CacheStore on a cold in-memory miss (e.g. right after a
+// restart). It's only for GetAll's network-failure fallback — normal cache
+// hits still go through getCachedKey, which respects TTL.
+func (c *Client) getCachedStale(env string) map[string]string {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
 	entry, ok := c.cache[env]
-	if !ok || time.Now().After(entry.expiresAt) {
-		return nil
+	c.mu.RUnlock()
+
+	if !ok {
+		entry = c.hydrateFromStore(env)
+		if entry == nil {
+			return nil
+		}
 	}
-	// Return a copy
+
 	result := make(map[string]string, len(entry.secrets))
 	for k, v := range entry.secrets {
 		result[k] = v
@@ -372,6 +457,35 @@ func (c *Client) getCached(env string) map[string]string {
 	return result
 }
 
+// hydrateFromStore loads env's persisted entry into the in-memory cache on
+// first touch, respecting the expiresAt it was saved with. No-op when no
+// CacheStore is configured or nothing is persisted for env.
+func (c *Client) hydrateFromStore(env string) *cacheEntry {
+	if c.cacheStore == nil {
+		return nil
+	}
+	secrets, expiresAt, err := c.cacheStore.Load(env)
+	if err != nil {
+		return nil // ErrCacheMiss or a store error — nothing to hydrate
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A concurrent network refresh may have already populated env; don't
+	// clobber it with the (possibly older) persisted copy.
+	if existing, ok := c.cache[env]; ok {
+		return existing
+	}
+	entry := &cacheEntry{secrets: secrets, expiresAt: expiresAt}
+	c.cache[env] = entry
+	if c.lastRefresh.IsZero() {
+		// Approximate when this was actually fetched, since HealthStatus
+		// should reflect the data's age, not the moment of this restart.
+		c.lastRefresh = expiresAt.Add(-c.cacheTTL)
+	}
+	return entry
+}
+
 func (c *Client) getCachedKey(env, key string) string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -383,18 +497,18 @@ func (c *Client) getCachedKey(env, key string) string {
 }
 
 func (c *Client) setCache(env string, secrets map[string]string) {
+	expiresAt := time.Now().Add(c.cacheTTL)
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.cache[env] = &cacheEntry{
-		secrets:   secrets,
-		expiresAt: time.Now().Add(c.cacheTTL),
-	}
+	c.cache[env] = &cacheEntry{secrets: secrets, expiresAt: expiresAt}
 	c.lastRefresh = time.Now()
+	c.mu.Unlock()
+
+	c.persistCache(env, secrets, expiresAt)
 }
 
 func (c *Client) setCachedKey(env, key, value string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	entry, ok := c.cache[env]
 	if !ok || time.Now().After(entry.expiresAt) {
 		entry = &cacheEntry{
@@ -404,6 +518,24 @@ func (c *Client) setCachedKey(env, key, value string) {
 		c.cache[env] = entry
 	}
 	entry.secrets[key] = value
+	secrets := make(map[string]string, len(entry.secrets))
+	for k, v := range entry.secrets {
+		secrets[k] = v
+	}
+	expiresAt := entry.expiresAt
+	c.mu.Unlock()
+
+	c.persistCache(env, secrets, expiresAt)
+}
+
+// persistCache mirrors a cache update to the configured CacheStore.
+// Best-effort: a persistence failure only degrades the crash-restart
+// fallback, so it must not fail the caller's Get/GetAll/Set.
+func (c *Client) persistCache(env string, secrets map[string]string, expiresAt time.Time) {
+	if c.cacheStore == nil {
+		return
+	}
+	_ = c.cacheStore.Save(env, secrets, expiresAt)
 }
 
 func (c *Client) request(ctx context.Context, method, path string, body any, result any) error {
@@ -418,6 +550,13 @@ func (c *Client) request(ctx context.Context, method, path string, body any, res
 		bodyReader = strings.NewReader(string(data))
 	}
 
+	token, err := c.resolveToken(ctx, false)
+	if err != nil {
+		return err
+	}
+	c.startAuthRefreshOnce()
+	reauthed := false
+
 	var lastErr error
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
@@ -425,7 +564,7 @@ func (c *Client) request(ctx context.Context, method, path string, body any, res
 			return fmt.Errorf("zvault: failed to create request: %w", err)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("User-Agent", userAgent)
 
@@ -444,6 +583,8 @@ func (c *Client) request(ctx context.Context, method, path string, body any, res
 			return lastErr
 		}
 
+		c.recordRateLimitHeaders(resp.Header)
+
 		defer resp.Body.Close()
 		respBody, err := io.ReadAll(resp.Body)
 		if err != nil {
@@ -467,6 +608,22 @@ func (c *Client) request(ctx context.Context, method, path string, body any, res
 			msg = apiErr.Error.Message
 		}
 
+		// A 401 may just mean our cached token expired early or was
+		// revoked — force one fresh login and retry before giving up.
+		if resp.StatusCode == http.StatusUnauthorized && c.cachedAuth != nil && !reauthed {
+			if fresh, ferr := c.resolveToken(ctx, true); ferr == nil {
+				token = fresh
+				reauthed = true
+				if attempt < c.maxRetries {
+					if body != nil {
+						data, _ := json.Marshal(body)
+						bodyReader = strings.NewReader(string(data))
+					}
+					continue
+				}
+			}
+		}
+
 		// Non-retryable errors
 		switch resp.StatusCode {
 		case http.StatusUnauthorized, http.StatusForbidden:
@@ -478,7 +635,7 @@ func (c *Client) request(ctx context.Context, method, path string, body any, res
 		// Retryable
 		lastErr = &APIError{StatusCode: resp.StatusCode, Message: msg}
 		if attempt < c.maxRetries && isRetryable(resp.StatusCode) {
-			sleepWithJitter(ctx, attempt)
+			sleepForRetry(ctx, attempt, resp.Header)
 			if body != nil {
 				data, _ := json.Marshal(body)
 				bodyReader = strings.NewReader(string(data))
@@ -507,14 +664,21 @@ func isRetryable(status int) bool {
 }
 
 func sleepWithJitter(ctx context.Context, attempt int) {
-	delay := retryBaseDelay * time.Duration(math.Pow(2, float64(attempt)))
-	jitter := time.Duration(rand.Int64N(int64(float64(delay) * 0.3)))
+	delay := backoffDelay(attempt)
 	select {
-	case <-time.After(delay + jitter):
+	case <-time.After(delay):
 	case <-ctx.Done():
 	}
 }
 
+// backoffDelay computes exponential backoff with up to 30% jitter for
+// attempt. It applies no cap itself; sleepForRetry caps at maxRetryDelay.
+func backoffDelay(attempt int) time.Duration {
+	delay := retryBaseDelay * time.Duration(math.Pow(2, float64(attempt)))
+	jitter := time.Duration(rand.Int64N(int64(float64(delay) * 0.3)))
+	return delay + jitter
+}
+
 func firstNonEmpty(vals ...string) string {
 	for _, v := range vals {
 		if v != "" {
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hashShort gives data sources backed by arbitrary input strings a stable,
+// compact resource ID without persisting the (sensitive) input itself.
+func hashShort(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// dataSourceTransitEncrypt and dataSourceTransitDecrypt call ZVault Cloud's
+// per-project transit key to encrypt/decrypt arbitrary plaintext without
+// ever persisting it as a secret — so ciphertext can be safely checked into
+// other Terraform state (e.g. passed to a different module or provider).
+
+func dataSourceTransitEncrypt() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTransitEncryptRead,
+		Schema: map[string]*schema.Schema{
+			"project":    {Type: schema.TypeString, Required: true},
+			"key":        {Type: schema.TypeString, Required: true, Description: "Name of the project's transit encryption key."},
+			"plaintext":  {Type: schema.TypeString, Required: true, Sensitive: true},
+			"ciphertext": {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+func dataSourceTransitEncryptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	key := d.Get("key").(string)
+	plaintext := d.Get("plaintext").(string)
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/transit/%s/encrypt", client.OrgID, project, key)
+	body, err := client.apiPost(ctx, path, map[string]string{"plaintext": plaintext})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := unmarshalInto(body, &resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/encrypt/%x", project, key, hashShort(plaintext)))
+	_ = d.Set("ciphertext", resp.Ciphertext)
+	return nil
+}
+
+func dataSourceTransitDecrypt() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTransitDecryptRead,
+		Schema: map[string]*schema.Schema{
+			"project":    {Type: schema.TypeString, Required: true},
+			"key":        {Type: schema.TypeString, Required: true, Description: "Name of the project's transit encryption key."},
+			"ciphertext": {Type: schema.TypeString, Required: true},
+			"plaintext":  {Type: schema.TypeString, Computed: true, Sensitive: true},
+		},
+	}
+}
+
+func dataSourceTransitDecryptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	key := d.Get("key").(string)
+	ciphertext := d.Get("ciphertext").(string)
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/transit/%s/decrypt", client.OrgID, project, key)
+	body, err := client.apiPost(ctx, path, map[string]string{"ciphertext": ciphertext})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := unmarshalInto(body, &resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/decrypt/%x", project, key, hashShort(ciphertext)))
+	_ = d.Set("plaintext", resp.Plaintext)
+	return nil
+}
@@ -7,15 +7,21 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/nicosalm/zvault/pkg/auth"
 )
 
 func main() {
@@ -30,10 +36,10 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("ZVAULT_TOKEN", nil),
-				Description: "ZVault service token (zvt_...)",
+				Description: "ZVault service token (zvt_...). Not required when `auth` is set.",
 			},
 			"org_id": {
 				Type:        schema.TypeString,
@@ -47,24 +53,61 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ZVAULT_URL", "https://api.zvault.cloud"),
 				Description: "ZVault Cloud API URL",
 			},
+			"auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Exchange an ambient credential for a ZVault token instead of using a static `token`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAuthMethod,
+							Description:  "One of \"kubernetes\", \"oidc\", \"aws_iam\".",
+						},
+						"role": {Type: schema.TypeString, Required: true},
+						"jwt": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Caller-supplied JWT, required for method = \"oidc\".",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AWS region override, only used by method = \"aws_iam\".",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"zvault_secret": resourceSecret(),
+			"zvault_secret":         resourceSecret(),
+			"zvault_dynamic_secret": resourceDynamicSecret(),
+			"zvault_secrets_json":   resourceSecretsJSON(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"zvault_secret":  dataSourceSecret(),
-			"zvault_secrets": dataSourceSecrets(),
+			"zvault_secret":          dataSourceSecret(),
+			"zvault_secrets":         dataSourceSecrets(),
+			"zvault_transit_encrypt": dataSourceTransitEncrypt(),
+			"zvault_transit_decrypt": dataSourceTransitDecrypt(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-// Client holds the ZVault API client configuration.
+// Client holds the ZVault API client configuration. When Authenticator is
+// set it takes priority over Token and is consulted (its result cached and
+// refreshed) on every request; otherwise Token is used directly.
 type Client struct {
-	Token   string
-	OrgID   string
-	BaseURL string
-	HTTP    *http.Client
+	Token         string
+	OrgID         string
+	BaseURL       string
+	HTTP          *http.Client
+	Authenticator auth.Authenticator
+
+	cachedAuth *auth.CachedAuthenticator
 }
 
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -72,38 +115,169 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 	orgID := d.Get("org_id").(string)
 	baseURL := d.Get("url").(string)
 
-	return &Client{
-		Token:   token,
-		OrgID:   orgID,
-		BaseURL: baseURL,
-		HTTP:    &http.Client{Timeout: 30 * time.Second},
-	}, nil
+	authenticator, diags := buildAuthenticator(d, baseURL)
+	if diags != nil {
+		return nil, diags
+	}
+	if token == "" && authenticator == nil {
+		return nil, diag.Errorf("zvault: either `token` or an `auth` block is required")
+	}
+
+	client := &Client{
+		Token:         token,
+		OrgID:         orgID,
+		BaseURL:       baseURL,
+		HTTP:          &http.Client{Timeout: 30 * time.Second},
+		Authenticator: authenticator,
+	}
+	if authenticator != nil {
+		client.cachedAuth = auth.NewCachedAuthenticator(authenticator)
+	}
+	return client, nil
+}
+
+func validateAuthMethod(v interface{}, key string) (warns []string, errs []error) {
+	switch v.(string) {
+	case "kubernetes", "oidc", "aws_iam":
+	default:
+		errs = append(errs, fmt.Errorf("%s must be one of \"kubernetes\", \"oidc\", \"aws_iam\", got %q", key, v))
+	}
+	return warns, errs
+}
+
+func buildAuthenticator(d *schema.ResourceData, baseURL string) (auth.Authenticator, diag.Diagnostics) {
+	blocks := d.Get("auth").([]interface{})
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	block := blocks[0].(map[string]interface{})
+	method := block["method"].(string)
+	role := block["role"].(string)
+
+	switch method {
+	case "kubernetes":
+		return auth.KubernetesAuth{BaseURL: baseURL, Role: role}, nil
+	case "oidc":
+		jwt, _ := block["jwt"].(string)
+		if jwt == "" {
+			return nil, diag.Errorf("zvault: auth.jwt is required when auth.method = \"oidc\"")
+		}
+		return auth.OIDCAuth{BaseURL: baseURL, Role: role, JWT: jwt}, nil
+	case "aws_iam":
+		region, _ := block["region"].(string)
+		return auth.AWSIAMAuth{BaseURL: baseURL, Role: role, Region: region}, nil
+	default:
+		return nil, diag.Errorf("zvault: unknown auth.method %q", method)
+	}
+}
+
+// resolveToken returns the token to send with a request, transparently
+// logging in (and caching the result) the first time an Authenticator is
+// used, and forcing a fresh login when force is true (used after a 401).
+func (c *Client) resolveToken(ctx context.Context, force bool) (string, error) {
+	if c.cachedAuth == nil {
+		return c.Token, nil
+	}
+	return c.cachedAuth.Token(ctx, force)
 }
 
 func (c *Client) apiGet(ctx context.Context, path string) ([]byte, error) {
-	url := fmt.Sprintf("%s/v1/cloud%s", c.BaseURL, path)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return c.apiBody(ctx, http.MethodGet, path, nil)
+}
+
+// apiPost issues a JSON POST and returns the raw response body.
+func (c *Client) apiPost(ctx context.Context, path string, payload any) ([]byte, error) {
+	return c.apiBody(ctx, http.MethodPost, path, payload)
+}
+
+// apiPut issues a JSON PUT and returns the raw response body.
+func (c *Client) apiPut(ctx context.Context, path string, payload any) ([]byte, error) {
+	return c.apiBody(ctx, http.MethodPut, path, payload)
+}
+
+// apiDelete issues a DELETE request, discarding any response body.
+func (c *Client) apiDelete(ctx context.Context, path string) error {
+	_, err := c.apiBody(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+func (c *Client) apiBody(ctx context.Context, method, path string, payload any) ([]byte, error) {
+	var rawBody []byte
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("zvault: failed to marshal request body: %w", err)
+		}
+		rawBody = data
+	}
+
+	body, status, err := c.doRequest(ctx, method, path, rawBody, false)
+	if err == nil && status == http.StatusUnauthorized && c.Authenticator != nil {
+		// Token may have been revoked or expired early — force a fresh
+		// login once and retry before giving up.
+		body, status, err = c.doRequest(ctx, method, path, rawBody, true)
+	}
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if status >= 400 {
+		return nil, &APIError{StatusCode: status, Message: string(body)}
+	}
+	return body, nil
+}
+
+// APIError is returned by apiBody for any non-2xx response, mirroring the
+// Go SDK's zvault.APIError so callers can branch on StatusCode instead of
+// string-matching the error text (see isUnsupportedBatch).
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ZVault API error %d: %s", e.StatusCode, e.Message)
+}
+
+func (c *Client) doRequest(ctx context.Context, method, path string, rawBody []byte, forceLogin bool) ([]byte, int, error) {
+	token, err := c.resolveToken(ctx, forceLogin)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var bodyReader io.Reader
+	if rawBody != nil {
+		bodyReader = strings.NewReader(string(rawBody))
+	}
+
+	url := fmt.Sprintf("%s/v1/cloud%s", c.BaseURL, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "zvault-terraform/0.1.0")
 
 	resp, err := c.HTTP.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	return body, resp.StatusCode, nil
+}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("ZVault API error %d: %s", resp.StatusCode, string(body))
+// unmarshalInto is a small helper so callers don't need to import
+// encoding/json just to parse an apiPost/apiGet response.
+func unmarshalInto(body []byte, v any) error {
+	if len(body) == 0 {
+		return nil
 	}
-	return body, nil
+	return json.Unmarshal(body, v)
 }
 
 // --- Data Source: zvault_secret ---
@@ -162,6 +336,12 @@ func dataSourceSecrets() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project":     {Type: schema.TypeString, Required: true},
 			"environment": {Type: schema.TypeString, Required: true},
+			"keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Restrict the result to this subset of keys instead of fetching every secret in the env.",
+			},
 			"secrets": {
 				Type:      schema.TypeMap,
 				Computed:  true,
@@ -177,45 +357,159 @@ func dataSourceSecretsRead(ctx context.Context, d *schema.ResourceData, meta int
 	project := d.Get("project").(string)
 	env := d.Get("environment").(string)
 
-	// Fetch keys
-	keysPath := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets", client.OrgID, project, env)
-	keysBody, err := client.apiGet(ctx, keysPath)
+	var filterKeys []string
+	for _, k := range d.Get("keys").([]interface{}) {
+		filterKeys = append(filterKeys, k.(string))
+	}
+
+	envPath := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets", client.OrgID, project, env)
+
+	keys := filterKeys
+	if len(keys) == 0 {
+		var err error
+		keys, err = client.listSecretKeys(ctx, envPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	secrets, failed, err := client.batchGetSecrets(ctx, envPath, keys)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var keysResp struct {
+	d.SetId(fmt.Sprintf("%s/%s", project, env))
+	_ = d.Set("secrets", secrets)
+
+	var diags diag.Diagnostics
+	if len(failed) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d of %d secrets could not be fetched", len(failed), len(keys)),
+			Detail:   fmt.Sprintf("keys skipped: %v", failed),
+		})
+	}
+	return diags
+}
+
+func (c *Client) listSecretKeys(ctx context.Context, envPath string) ([]string, error) {
+	body, err := c.apiGet(ctx, envPath)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
 		Keys []struct {
 			Key string `json:"key"`
 		} `json:"keys"`
 	}
-	if err := json.Unmarshal(keysBody, &keysResp); err != nil {
-		return diag.FromErr(err)
+	if err := unmarshalInto(body, &resp); err != nil {
+		return nil, err
 	}
+	keys := make([]string, len(resp.Keys))
+	for i, k := range resp.Keys {
+		keys[i] = k.Key
+	}
+	return keys, nil
+}
 
-	// Fetch each secret
-	secrets := make(map[string]string, len(keysResp.Keys))
-	for _, k := range keysResp.Keys {
-		secretPath := fmt.Sprintf("%s/%s", keysPath, k.Key)
-		secretBody, err := client.apiGet(ctx, secretPath)
-		if err != nil {
-			continue
-		}
-		var secretResp struct {
-			Secret struct {
-				Value string `json:"value"`
-			} `json:"secret"`
-		}
-		if err := json.Unmarshal(secretBody, &secretResp); err != nil {
-			continue
+// batchGetSecrets fetches keys in a single round-trip via the
+// secrets:batchGet endpoint, falling back to a bounded worker pool of
+// per-key GETs if the server doesn't support batching yet (404/501).
+// Keys that fail even in the fallback path are returned in failed instead
+// of silently dropped.
+func (c *Client) batchGetSecrets(ctx context.Context, envPath string, keys []string) (secrets map[string]string, failed []string, err error) {
+	secrets, err = c.batchGetViaEndpoint(ctx, envPath, keys)
+	if err == nil {
+		return secrets, missingKeys(keys, secrets), nil
+	}
+	if !isUnsupportedBatch(err) {
+		return nil, nil, err
+	}
+	return c.batchGetViaWorkerPool(ctx, envPath, keys, batchFetchConcurrency)
+}
+
+// missingKeys returns the keys requested but not present in the batchGet
+// response, so a caller filtering on a nonexistent key sees it in failed
+// instead of the endpoint path silently returning fewer secrets than asked.
+func missingKeys(requested []string, got map[string]string) []string {
+	var missing []string
+	for _, k := range requested {
+		if _, ok := got[k]; !ok {
+			missing = append(missing, k)
 		}
-		secrets[k.Key] = secretResp.Secret.Value
 	}
+	return missing
+}
 
-	d.SetId(fmt.Sprintf("%s/%s", project, env))
-	_ = d.Set("secrets", secrets)
+const batchFetchConcurrency = 8
 
-	return nil
+func (c *Client) batchGetViaEndpoint(ctx context.Context, envPath string, keys []string) (map[string]string, error) {
+	body, err := c.apiPost(ctx, envPath+":batchGet", map[string]any{"keys": keys})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Secrets []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"secrets"`
+	}
+	if err := unmarshalInto(body, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(resp.Secrets))
+	for _, s := range resp.Secrets {
+		out[s.Key] = s.Value
+	}
+	return out, nil
+}
+
+func isUnsupportedBatch(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented
+}
+
+func (c *Client) batchGetViaWorkerPool(ctx context.Context, envPath string, keys []string, concurrency int) (map[string]string, []string, error) {
+	var (
+		mu      sync.Mutex
+		secrets = make(map[string]string, len(keys))
+		failed  []string
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for _, key := range keys {
+		key := key
+		g.Go(func() error {
+			body, err := c.apiGet(gctx, fmt.Sprintf("%s/%s", envPath, key))
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, key)
+				return nil // partial failures are reported, not fatal
+			}
+			var resp struct {
+				Secret struct {
+					Value string `json:"value"`
+				} `json:"secret"`
+			}
+			if err := unmarshalInto(body, &resp); err != nil {
+				failed = append(failed, key)
+				return nil
+			}
+			secrets[key] = resp.Secret.Value
+			return nil
+		})
+	}
+	_ = g.Wait() // errors are collected into `failed`, never returned here
+
+	return secrets, failed, nil
 }
 
 // --- Resource: zvault_secret ---
@@ -258,37 +552,10 @@ func resourceSecretUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	comment := d.Get("comment").(string)
 
 	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets/%s", client.OrgID, project, env, key)
-	url := fmt.Sprintf("%s/v1/cloud%s", client.BaseURL, path)
-
-	payload := fmt.Sprintf(`{"value":%q,"comment":%q}`, value, comment)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, io.NopCloser(
-		io.Reader(nil),
-	))
-	if err != nil {
+	if _, err := client.apiPut(ctx, path, map[string]string{"value": value, "comment": comment}); err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Use strings.NewReader for the body
-	req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	req.Body = io.NopCloser(stringReader(payload))
-	req.Header.Set("Authorization", "Bearer "+client.Token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "zvault-terraform/0.1.0")
-
-	resp, err := client.HTTP.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return diag.Errorf("ZVault API error %d: %s", resp.StatusCode, string(body))
-	}
-
 	d.SetId(fmt.Sprintf("%s/%s/%s", project, env, key))
 	return resourceSecretRead(ctx, d, meta)
 }
@@ -300,43 +567,13 @@ func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	key := d.Get("key").(string)
 
 	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets/%s", client.OrgID, project, env, key)
-	url := fmt.Sprintf("%s/v1/cloud%s", client.BaseURL, path)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
-	if err != nil {
+	if err := client.apiDelete(ctx, path); err != nil {
 		return diag.FromErr(err)
 	}
-	req.Header.Set("Authorization", "Bearer "+client.Token)
-	req.Header.Set("User-Agent", "zvault-terraform/0.1.0")
-
-	resp, err := client.HTTP.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
 
 	d.SetId("")
 	return nil
 }
 
-// stringReader wraps a string as an io.Reader.
-func stringReader(s string) io.Reader {
-	return &stringReaderImpl{data: []byte(s)}
-}
-
-type stringReaderImpl struct {
-	data []byte
-	pos  int
-}
-
-func (r *stringReaderImpl) Read(p []byte) (int, error) {
-	if r.pos >= len(r.data) {
-		return 0, io.EOF
-	}
-	n := copy(p, r.data[r.pos:])
-	r.pos += n
-	return n, nil
-}
-
 // Ensure os is used (for env var fallback in provider schema).
 var _ = os.Getenv
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// leaseInfo is the lease metadata ZVault Cloud returns for a dynamic secret.
+type leaseInfo struct {
+	LeaseID        string    `json:"lease_id"`
+	LeaseDuration  int       `json:"lease_duration"` // seconds
+	LeaseStartTime time.Time `json:"lease_start_time"`
+	Renewable      bool      `json:"renewable"`
+}
+
+// expiresAt returns the wall-clock time the lease is no longer valid.
+func (l leaseInfo) expiresAt() time.Time {
+	return l.LeaseStartTime.Add(time.Duration(l.LeaseDuration) * time.Second)
+}
+
+// renewAfter returns the point at which the lease should be renewed,
+// modeled on Vault's api/renewer.go grace-window calculation: renew once
+// roughly 2/3 of the way through the lease rather than waiting until expiry.
+func (l leaseInfo) renewAfter(buffer time.Duration) time.Time {
+	ttl := time.Duration(l.LeaseDuration) * time.Second
+	grace := ttl * 2 / 3
+	if buffer > 0 && grace > ttl-buffer {
+		grace = ttl - buffer
+	}
+	return l.LeaseStartTime.Add(grace)
+}
+
+// expired reports whether the lease is past its expiry as of now.
+func (l leaseInfo) expired() bool {
+	return time.Now().After(l.expiresAt())
+}
+
+// LeaseRenewer renews a single ZVault dynamic secret lease, following the
+// same renew-at-grace-window, fall-back-to-recreate contract as Vault's
+// renewer: callers are expected to call MaybeRenew on every Terraform
+// refresh and treat a false ok result as "recreate this resource".
+type LeaseRenewer struct {
+	client *Client
+	org    string
+	minTTL int
+}
+
+// NewLeaseRenewer builds a renewer bound to a single org.
+func NewLeaseRenewer(client *Client, org string, minTTL int) *LeaseRenewer {
+	return &LeaseRenewer{client: client, org: org, minTTL: minTTL}
+}
+
+// MaybeRenew renews lease if it is inside its grace window, and reports
+// whether the caller's stored lease is still usable. A false return means
+// the lease has expired or its remaining TTL is under minTTL, and the
+// resource must be recreated. A failed renewal of an unexpired lease
+// returns true along with the error.
+func (r *LeaseRenewer) MaybeRenew(ctx context.Context, lease leaseInfo, increment, renewBuffer time.Duration) (leaseInfo, bool, error) {
+	if lease.expired() {
+		return lease, false, nil
+	}
+	if r.minTTL > 0 && time.Until(lease.expiresAt()) < time.Duration(r.minTTL)*time.Second {
+		// Remaining TTL is already under the configured floor — force a
+		// fresh lease rather than renew this one, since min_ttl exists
+		// precisely to guarantee callers never see less than that much
+		// headroom after a refresh.
+		return lease, false, nil
+	}
+	if !lease.Renewable || time.Now().Before(lease.renewAfter(renewBuffer)) {
+		return lease, true, nil
+	}
+
+	renewed, err := r.renew(ctx, lease.LeaseID, increment)
+	if err != nil {
+		// A failed renewal doesn't evict the lease immediately — it may
+		// still be valid until expiresAt(), so surface the error but let
+		// the caller decide whether to keep using the stale lease.
+		return lease, !lease.expired(), err
+	}
+	return renewed, true, nil
+}
+
+func (r *LeaseRenewer) renew(ctx context.Context, leaseID string, increment time.Duration) (leaseInfo, error) {
+	path := fmt.Sprintf("/orgs/%s/leases/%s/renew", r.org, leaseID)
+	body := map[string]any{"increment_seconds": int(increment.Seconds())}
+
+	respBody, err := r.client.apiPost(ctx, path, body)
+	if err != nil {
+		return leaseInfo{}, err
+	}
+
+	var resp struct {
+		Lease leaseInfo `json:"lease"`
+	}
+	if err := unmarshalInto(respBody, &resp); err != nil {
+		return leaseInfo{}, err
+	}
+	return resp.Lease, nil
+}
+
+func (r *LeaseRenewer) revoke(ctx context.Context, leaseID string) error {
+	path := fmt.Sprintf("/orgs/%s/leases/%s", r.org, leaseID)
+	return r.client.apiDelete(ctx, path)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDynamicSecret manages a leased/dynamic secret (e.g. a database
+// credential or cloud token minted on demand by ZVault Cloud). Unlike
+// zvault_secret, the value here is issued by the backend and carries a
+// lease that must be renewed or the credential expires.
+func resourceDynamicSecret() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynamicSecretCreate,
+		ReadContext:   resourceDynamicSecretRead,
+		UpdateContext: resourceDynamicSecretUpdate,
+		DeleteContext: resourceDynamicSecretDelete,
+		Schema: map[string]*schema.Schema{
+			"project":     {Type: schema.TypeString, Required: true, ForceNew: true},
+			"environment": {Type: schema.TypeString, Required: true, ForceNew: true},
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Dynamic secret engine path, e.g. \"database/creds/readonly\".",
+			},
+			"min_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+				Description: "Minimum acceptable lease TTL in seconds before Terraform requests a fresh lease.",
+			},
+			"increment": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3600,
+				Description: "Seconds requested on each renewal call.",
+			},
+			"renew_buffer": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "Seconds of slack kept before lease expiry when deciding whether to renew.",
+			},
+			"max_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Hard ceiling on total lease lifetime in seconds. 0 means no provider-side limit.",
+			},
+			"value":            {Type: schema.TypeMap, Computed: true, Sensitive: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"lease_id":         {Type: schema.TypeString, Computed: true},
+			"lease_duration":   {Type: schema.TypeInt, Computed: true},
+			"lease_start_time": {Type: schema.TypeString, Computed: true},
+			"renewable":        {Type: schema.TypeBool, Computed: true},
+			"lease_created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 time the lease was first issued. Unlike lease_start_time, this is never reset by a renewal, so max_ttl can bound the lease's total lifetime.",
+			},
+		},
+	}
+}
+
+func resourceDynamicSecretCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	env := d.Get("environment").(string)
+	path := d.Get("path").(string)
+	minTTL := d.Get("min_ttl").(int)
+
+	issuePath := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/dynamic-secrets/%s", client.OrgID, project, env, path)
+	body, err := client.apiPost(ctx, issuePath, map[string]any{"min_ttl_seconds": minTTL})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resp struct {
+		Value map[string]string `json:"value"`
+		Lease leaseInfo         `json:"lease"`
+	}
+	if err := unmarshalInto(body, &resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.Lease.LeaseID)
+	// lease_created_at is the original issuance time; unlike
+	// lease_start_time it must only be set here, not on every renewal.
+	_ = d.Set("lease_created_at", resp.Lease.LeaseStartTime.Format(time.RFC3339))
+	return setDynamicSecretState(d, resp.Value, resp.Lease)
+}
+
+func resourceDynamicSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	maxTTL := d.Get("max_ttl").(int)
+	increment := time.Duration(d.Get("increment").(int)) * time.Second
+	renewBuffer := time.Duration(d.Get("renew_buffer").(int)) * time.Second
+
+	lease, diags := dynamicSecretLeaseFromState(d)
+	if diags != nil {
+		return diags
+	}
+
+	renewer := NewLeaseRenewer(client, client.OrgID, d.Get("min_ttl").(int))
+	renewed, ok, err := renewer.MaybeRenew(ctx, lease, increment, renewBuffer)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "failed to renew ZVault dynamic secret lease",
+			Detail:   fmt.Sprintf("lease %s for %s: %s (will keep serving the cached credential until it expires)", lease.LeaseID, project, err),
+		}}
+	}
+	if !ok {
+		// Lease expired or has less than min_ttl left — drop the ID so
+		// Terraform plans a recreate instead of trusting a dying lease.
+		d.SetId("")
+		return nil
+	}
+	if maxTTL > 0 {
+		createdAt, err := time.Parse(time.RFC3339, d.Get("lease_created_at").(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("zvault: invalid lease_created_at in state: %w", err))
+		}
+		if time.Since(createdAt) >= time.Duration(maxTTL)*time.Second {
+			d.SetId("")
+			return nil
+		}
+	}
+
+	return setLeaseState(d, renewed)
+}
+
+func resourceDynamicSecretUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only min_ttl/increment/renew_buffer/max_ttl (non-ForceNew) can change,
+	// and they only affect how later reads renew or recreate the lease —
+	// nothing to push to the server.
+	return resourceDynamicSecretRead(ctx, d, meta)
+}
+
+func resourceDynamicSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	renewer := NewLeaseRenewer(client, client.OrgID, 0)
+	if err := renewer.revoke(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func setDynamicSecretState(d *schema.ResourceData, value map[string]string, lease leaseInfo) diag.Diagnostics {
+	if err := d.Set("value", value); err != nil {
+		return diag.FromErr(err)
+	}
+	return setLeaseState(d, lease)
+}
+
+func setLeaseState(d *schema.ResourceData, lease leaseInfo) diag.Diagnostics {
+	_ = d.Set("lease_id", lease.LeaseID)
+	_ = d.Set("lease_duration", lease.LeaseDuration)
+	_ = d.Set("lease_start_time", lease.LeaseStartTime.Format(time.RFC3339))
+	_ = d.Set("renewable", lease.Renewable)
+	return nil
+}
+
+func dynamicSecretLeaseFromState(d *schema.ResourceData) (leaseInfo, diag.Diagnostics) {
+	start, err := time.Parse(time.RFC3339, d.Get("lease_start_time").(string))
+	if err != nil {
+		return leaseInfo{}, diag.FromErr(fmt.Errorf("zvault: invalid lease_start_time in state: %w", err))
+	}
+	return leaseInfo{
+		LeaseID:        d.Id(),
+		LeaseDuration:  d.Get("lease_duration").(int),
+		LeaseStartTime: start,
+		Renewable:      d.Get("renewable").(bool),
+	}, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSecretsJSON manages a single secret whose value is a full JSON
+// document (e.g. a service account key or a structured config blob),
+// stored as one PUT instead of being split into individual keys.
+func resourceSecretsJSON() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretsJSONCreate,
+		ReadContext:   resourceSecretsJSONRead,
+		UpdateContext: resourceSecretsJSONUpdate,
+		DeleteContext: resourceSecretsJSONDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecretsJSONImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project":     {Type: schema.TypeString, Required: true, ForceNew: true},
+			"environment": {Type: schema.TypeString, Required: true, ForceNew: true},
+			"key":         {Type: schema.TypeString, Required: true, ForceNew: true},
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateFunc:     validateJSON,
+				DiffSuppressFunc: diffSuppressSemanticJSON,
+				Description:      "A JSON document stored as a single secret. Key ordering and whitespace don't affect diffing.",
+			},
+			"comment": {Type: schema.TypeString, Optional: true, Default: ""},
+			"version": {Type: schema.TypeInt, Computed: true},
+		},
+	}
+}
+
+func validateJSON(v interface{}, key string) (warns []string, errs []error) {
+	if !json.Valid([]byte(v.(string))) {
+		errs = append(errs, fmt.Errorf("%s must be valid JSON", key))
+	}
+	return warns, errs
+}
+
+// diffSuppressSemanticJSON treats two JSON documents as equal if they
+// marshal to the same canonical form, so re-ordered keys or reformatted
+// whitespace don't produce a spurious plan diff.
+func diffSuppressSemanticJSON(_, old, new string, _ *schema.ResourceData) bool {
+	oldCanon, err := canonicalJSON(old)
+	if err != nil {
+		return false
+	}
+	newCanon, err := canonicalJSON(new)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(oldCanon, newCanon)
+}
+
+func canonicalJSON(raw string) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// resourceSecretsJSONImport splits the "project/environment/key" import ID
+// into its component fields. Plain passthrough only sets d.Id(), leaving
+// project/environment/key empty for the Read that follows import.
+func resourceSecretsJSONImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("zvault: invalid import ID %q, expected project/environment/key", d.Id())
+	}
+	_ = d.Set("project", parts[0])
+	_ = d.Set("environment", parts[1])
+	_ = d.Set("key", parts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSecretsJSONCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceSecretsJSONUpdate(ctx, d, meta)
+}
+
+func resourceSecretsJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	env := d.Get("environment").(string)
+	key := d.Get("key").(string)
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets/%s", client.OrgID, project, env, key)
+	body, err := client.apiGet(ctx, path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resp struct {
+		Secret struct {
+			Value   string `json:"value"`
+			Version int    `json:"version"`
+		} `json:"secret"`
+	}
+	if err := unmarshalInto(body, &resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, env, key))
+	_ = d.Set("json", resp.Secret.Value)
+	_ = d.Set("version", resp.Secret.Version)
+	return nil
+}
+
+func resourceSecretsJSONUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	env := d.Get("environment").(string)
+	key := d.Get("key").(string)
+	value := d.Get("json").(string)
+	comment := d.Get("comment").(string)
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets/%s", client.OrgID, project, env, key)
+	if _, err := client.apiPut(ctx, path, map[string]string{"value": value, "comment": comment}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, env, key))
+	return resourceSecretsJSONRead(ctx, d, meta)
+}
+
+func resourceSecretsJSONDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	project := d.Get("project").(string)
+	env := d.Get("environment").(string)
+	key := d.Get("key").(string)
+
+	path := fmt.Sprintf("/orgs/%s/projects/%s/envs/%s/secrets/%s", client.OrgID, project, env, key)
+	if err := client.apiDelete(ctx, path); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}